@@ -0,0 +1,317 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	notificationCodeAchievement = 111
+
+	// Each user's progress is kept in a single object, readable by its owner.
+	achievementCollection = "achievement"
+	achievementKey        = "progress"
+
+	achievementWriteAttempts = 3
+)
+
+// Events achievements can count. Each event adds to an achievement's progress, except win streaks, which set it to the
+// longest streak so far.
+const (
+	achievementEventRoundPlayed  = "round_played"
+	achievementEventRoundWon     = "round_won"
+	achievementEventWinStreak    = "win_streak"
+	achievementEventDiagonalWin  = "diagonal_win"
+	achievementEventFastAIWin    = "fast_ai_win"
+	achievementEventDailyReward  = "daily_reward"
+	achievementEventSessionStart = "session_start"
+)
+
+var achievementEvents = map[string]bool{
+	achievementEventRoundPlayed:  true,
+	achievementEventRoundWon:     true,
+	achievementEventWinStreak:    true,
+	achievementEventDiagonalWin:  true,
+	achievementEventFastAIWin:    true,
+	achievementEventDailyReward:  true,
+	achievementEventSessionStart: true,
+}
+
+// The achievements players can unlock, loaded when the module starts.
+//
+//go:embed achievements.json
+var achievementsJSON []byte
+
+type achievementDefinition struct {
+	ID          string           `json:"id"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+	Event       string           `json:"event"`
+	Target      int64            `json:"target"`
+	Reward      map[string]int64 `json:"reward"`
+}
+
+type achievementDefinitions []*achievementDefinition
+
+// An achievement progress storage object for a user.
+type achievementProgress struct {
+	Progress  map[string]int64 `json:"progress"`   // Progress by achievement ID.
+	Unlocked  map[string]int64 `json:"unlocked"`   // When each achievement was unlocked in UNIX time, by ID.
+	WinStreak int64            `json:"win_streak"` // Rounds won in a row, up to the latest one.
+}
+
+func loadAchievements(raw []byte) (achievementDefinitions, error) {
+	var definitions achievementDefinitions
+	if err := json.Unmarshal(raw, &definitions); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool, len(definitions))
+	for _, d := range definitions {
+		switch {
+		case d.ID == "" || ids[d.ID]:
+			return nil, fmt.Errorf("achievement ID %q is empty or repeated", d.ID)
+		case !achievementEvents[d.Event]:
+			return nil, fmt.Errorf("achievement %q has unknown event %q", d.ID, d.Event)
+		case d.Target < 1:
+			return nil, fmt.Errorf("achievement %q target must be at least 1", d.ID)
+		}
+		ids[d.ID] = true
+	}
+	return definitions, nil
+}
+
+// Check if the winning line runs diagonally, each position one row down and one column across from the last.
+func isDiagonal(positions []int32, width int) bool {
+	if len(positions) < 2 || width < 2 {
+		return false
+	}
+	positions = slices.Clone(positions)
+	slices.Sort(positions)
+	step := 0
+	for i := 1; i < len(positions); i++ {
+		prev, pos := int(positions[i-1]), int(positions[i])
+		rows, cols := pos/width-prev/width, pos%width-prev%width
+		if rows != 1 || (cols != 1 && cols != -1) || (step != 0 && cols != step) {
+			return false
+		}
+		step = cols
+	}
+	return true
+}
+
+func readAchievementProgress(ctx context.Context, nk runtime.NakamaModule, userID string) (*achievementProgress, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: achievementCollection,
+		Key:        achievementKey,
+		UserID:     userID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	progress := &achievementProgress{}
+	version := "*"
+	if len(objects) > 0 {
+		if err := json.Unmarshal([]byte(objects[0].GetValue()), progress); err != nil {
+			return nil, "", err
+		}
+		version = objects[0].GetVersion()
+	}
+	if progress.Progress == nil {
+		progress.Progress = make(map[string]int64)
+	}
+	if progress.Unlocked == nil {
+		progress.Unlocked = make(map[string]int64)
+	}
+	return progress, version, nil
+}
+
+// Count events towards the user's achievements, and return any achievements that are now unlocked.
+func (d achievementDefinitions) apply(progress *achievementProgress, events []string, t time.Time) []*achievementDefinition {
+	counts := make(map[string]int64, len(events))
+	for _, event := range events {
+		counts[event]++
+	}
+	if counts[achievementEventRoundPlayed] > 0 {
+		if counts[achievementEventRoundWon] > 0 {
+			progress.WinStreak++
+		} else {
+			progress.WinStreak = 0
+		}
+	}
+
+	var unlocked []*achievementDefinition
+	for _, definition := range d {
+		if _, ok := progress.Unlocked[definition.ID]; ok {
+			continue
+		}
+		if definition.Event == achievementEventWinStreak {
+			progress.Progress[definition.ID] = max(progress.Progress[definition.ID], progress.WinStreak)
+		} else {
+			progress.Progress[definition.ID] += counts[definition.Event]
+		}
+		if progress.Progress[definition.ID] >= definition.Target {
+			progress.Progress[definition.ID] = definition.Target
+			progress.Unlocked[definition.ID] = t.Unix()
+			unlocked = append(unlocked, definition)
+		}
+	}
+	return unlocked
+}
+
+// Record events for a user. Newly unlocked achievements grant their rewards in the same write as the progress, and the
+// user is notified of each one.
+func (d achievementDefinitions) record(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, userID string, events ...string) {
+	if len(d) == 0 || len(events) == 0 {
+		return
+	}
+
+	t := time.Now()
+	for attempt := 1; ; attempt++ {
+		progress, version, err := readAchievementProgress(ctx, nk, userID)
+		if err != nil {
+			logger.WithField("user_id", userID).Error("error reading achievements: %v", err)
+			return
+		}
+		unlocked := d.apply(progress, events, t)
+
+		value, err := json.Marshal(progress)
+		if err != nil {
+			logger.Error("Marshal error: %v", err)
+			return
+		}
+		writes := []*runtime.StorageWrite{{
+			Collection:      achievementCollection,
+			Key:             achievementKey,
+			UserID:          userID,
+			Value:           string(value),
+			Version:         version,
+			PermissionRead:  1, // Only the owner can read.
+			PermissionWrite: 0, // No client write.
+		}}
+		var wallets []*runtime.WalletUpdate
+		for _, definition := range unlocked {
+			if len(definition.Reward) > 0 {
+				wallets = append(wallets, &runtime.WalletUpdate{
+					UserID:    userID,
+					Changeset: definition.Reward,
+					Metadata:  map[string]interface{}{"achievement": definition.ID},
+				})
+			}
+		}
+
+		if _, _, err := nk.MultiUpdate(ctx, nil, writes, nil, wallets, true); err != nil {
+			if attempt >= achievementWriteAttempts || !isVersionConflict(err) {
+				logger.WithField("user_id", userID).Error("error recording achievements: %v", err)
+				return
+			}
+			continue
+		}
+
+		if len(unlocked) == 0 {
+			return
+		}
+		notifications := make([]*runtime.NotificationSend, 0, len(unlocked))
+		for _, definition := range unlocked {
+			notifications = append(notifications, &runtime.NotificationSend{
+				UserID:  userID,
+				Subject: fmt.Sprintf("Achievement unlocked: %s", definition.Name),
+				Content: map[string]interface{}{
+					"achievement_id": definition.ID,
+					"reward":         definition.Reward,
+				},
+				Code:       notificationCodeAchievement,
+				Persistent: true,
+			})
+		}
+		if err := nk.NotificationsSend(ctx, notifications); err != nil {
+			logger.Error("NotificationsSend error: %v", err)
+		}
+		return
+	}
+}
+
+// Count the round that just ended towards every human player's achievements.
+func (m *MatchHandler) recordRoundAchievements(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord) {
+	for userID, mark := range r.marks {
+		if userID == aiUserId {
+			continue
+		}
+
+		events := []string{achievementEventRoundPlayed}
+		if r.winner == mark {
+			events = append(events, achievementEventRoundWon)
+			if isDiagonal(r.winnerPositions, r.width) {
+				events = append(events, achievementEventDiagonalWin)
+			}
+			if r.fast && r.userIDForMark(opponentMark(mark)) == aiUserId {
+				events = append(events, achievementEventFastAIWin)
+			}
+		}
+		m.achievements.record(ctx, logger, nk, userID, events...)
+	}
+}
+
+func rpcListAchievements(marshaler *protojson.MarshalOptions, definitions achievementDefinitions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
+		}
+
+		progress, _, err := readAchievementProgress(ctx, nk, userID)
+		if err != nil {
+			logger.Error("error reading achievements: %v", err)
+			return "", errInternalError
+		}
+
+		achievements := make([]*api.Achievement, 0, len(definitions))
+		for _, definition := range definitions {
+			achievements = append(achievements, &api.Achievement{
+				Id:          definition.ID,
+				Name:        definition.Name,
+				Description: definition.Description,
+				Target:      definition.Target,
+				Progress:    progress.Progress[definition.ID],
+				UnlockTime:  progress.Unlocked[definition.ID],
+				Reward:      definition.Reward,
+			})
+		}
+
+		response, err := marshaler.Marshal(&api.RpcListAchievementsResponse{Achievements: achievements})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
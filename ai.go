@@ -15,19 +15,30 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"io"
-	"math"
-	"net/http"
+	"context"
 	"time"
 
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
-const aiUserId = "ai-user-id"
+const (
+	aiUserId = "ai-user-id"
+
+	aiWorkerCount    = 4
+	aiQueueSize      = 128
+	aiRequestTimeout = 2 * time.Second
+	// Room for the current request's result and a late one from the previous game.
+	aiResultBufferSize = 2
+
+	metricAIQueueDepth       = "ai_queue_depth"
+	metricAIQueueRejected    = "ai_queue_rejected"
+	metricAIQueueWait        = "ai_queue_wait"
+	metricAIInferenceLatency = "ai_inference_latency"
+	metricAIInferenceErrors  = "ai_inference_errors"
+	metricAIFallbackMoves    = "ai_fallback_moves"
+)
 
 var aiPresenceObj = &aiPresence{}
 
@@ -38,6 +49,10 @@ type aiPresence struct{}
 type aiMatchData struct {
 	opCode api.OpCode
 	data   []byte
+	// The AI request this result answers, used to discard stale results.
+	seq int64
+	// Set if the AI failed to produce a move.
+	err error
 	*aiPresence
 }
 
@@ -89,92 +104,98 @@ func (amd *aiMatchData) GetReceiveTime() int64 {
 	return time.Now().UTC().Unix()
 }
 
-type cell [2]int
-type row [3]cell
-type board [3]row
-
-type tfRequest struct {
-	Instances [1]board `json:"instances"`
-}
-
-type tfResponse struct {
-	Predictions [][]float64 `json:"predictions"`
-}
-
-func (m *MatchHandler) aiTurn(s *MatchState) error {
-	// Convert board state into expected model format
-	b := board{}
-
-	for i, mark := range s.board {
-		rowIdx := i / 3
-		cellIdx := i % 3
-
-		switch mark {
-		case s.marks[aiUserId]: // AI
-			b[rowIdx][cellIdx] = cell{1, 0}
-		case api.Mark_MARK_UNSPECIFIED:
-			b[rowIdx][cellIdx] = cell{0, 0}
-		default: // Player
-			b[rowIdx][cellIdx] = cell{0, 1}
-		}
+// A single request for the AI to pick a move for the given board.
+type aiJob struct {
+	player     AIPlayer
+	rules      GameRules
+	dimensions BoardDimensions
+	board      []api.Mark
+	aiMark     api.Mark
+	seq        int64
+	messages   chan runtime.MatchData
+	queuedAt   time.Time
+}
+
+// A bounded pool of workers that run AI inference outside the match loop. Results are fed back to the match
+// through its messages channel so a slow or unreachable model server never stalls a match tick.
+type aiWorkerPool struct {
+	logger    runtime.Logger
+	nk        runtime.NakamaModule
+	marshaler *protojson.MarshalOptions
+	timeout   time.Duration
+	jobs      chan *aiJob
+}
+
+func newAIWorkerPool(logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, workers, queueSize int, timeout time.Duration) *aiWorkerPool {
+	p := &aiWorkerPool{
+		logger:    logger,
+		nk:        nk,
+		marshaler: marshaler,
+		timeout:   timeout,
+		jobs:      make(chan *aiJob, queueSize),
 	}
-
-	// Send the vectors to TF
-	req := tfRequest{Instances: [1]board{b}}
-	raw, err := json.Marshal(req)
-	if err != nil {
-		return fmt.Errorf("failed to marshal TF request: %w", err)
-	}
-
-	resp, err := http.Post(
-		m.tfServingAddress, "application/json", bytes.NewReader(raw))
-
-	if err != nil {
-		return fmt.Errorf("failed to make TF request: %w", err)
+	for i := 0; i < workers; i++ {
+		go p.run()
 	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to make TF request: %w", err)
+	return p
+}
+
+// Submit queues a request for an AI move without blocking. Returns false if the queue is full.
+func (p *aiWorkerPool) Submit(rules GameRules, s *MatchState) bool {
+	board := make([]api.Mark, len(s.board))
+	copy(board, s.board)
+
+	job := &aiJob{
+		player:     s.aiPlayer,
+		rules:      rules,
+		dimensions: s.dimensions,
+		board:      board,
+		aiMark:     s.marks[aiUserId],
+		seq:        s.aiSeq,
+		messages:   s.messages,
+		queuedAt:   time.Now(),
 	}
 
-	// Convert response into message
-	predictions := tfResponse{}
-	if err := json.Unmarshal(respBody, &predictions); err != nil {
-		return fmt.Errorf("failed to unmarshal TF response: %w", err)
+	select {
+	case p.jobs <- job:
+		p.nk.MetricsGaugeSet(metricAIQueueDepth, nil, float64(len(p.jobs)))
+		return true
+	default:
+		p.nk.MetricsCounterAdd(metricAIQueueRejected, nil, 1)
+		return false
 	}
+}
 
-	if len(predictions.Predictions) != 1 {
-		return fmt.Errorf("received unexpected TF response: %w", err)
-	}
+func (p *aiWorkerPool) run() {
+	for job := range p.jobs {
+		p.nk.MetricsGaugeSet(metricAIQueueDepth, nil, float64(len(p.jobs)))
+		p.nk.MetricsTimerRecord(metricAIQueueWait, nil, time.Since(job.queuedAt))
 
-	// Find the index with the highest predicted value
-	maxVal := math.Inf(-1)
-	aiMovePos := -1
-	for i, val := range predictions.Predictions[0] {
-		if val > maxVal {
-			maxVal = val
-			aiMovePos = i
+		result := &aiMatchData{
+			opCode:     api.OpCode_OPCODE_MOVE,
+			seq:        job.seq,
+			aiPresence: aiPresenceObj,
 		}
-	}
 
-	// Append message to m.messages to be consumed by the next loop run
-	if aiMovePos > -1 {
-		move := &api.Move{Position: int32(aiMovePos)}
-		rawMove, err := m.marshaler.Marshal(move)
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		position, err := job.player.Move(ctx, job.rules, job.dimensions, job.board, job.aiMark)
+		cancel()
+		tags := map[string]string{"player": job.player.Name()}
+		p.nk.MetricsTimerRecord(metricAIInferenceLatency, tags, time.Since(start))
+
 		if err != nil {
-			return fmt.Errorf("failed to marshal AI move: %w", err)
+			p.nk.MetricsCounterAdd(metricAIInferenceErrors, tags, 1)
+		} else {
+			result.data, err = p.marshaler.Marshal(&api.Move{Position: int32(position)})
 		}
+		result.err = err
 
-		data := &aiMatchData{
-			opCode:     api.OpCode_OPCODE_MOVE,
-			data:       rawMove,
-			aiPresence: aiPresenceObj,
+		// The match has at most one request in flight per game, but it may have ended in the meantime so never block.
+		select {
+		case job.messages <- result:
+		default:
+			p.logger.Debug("dropping AI result for match that is no longer reading")
 		}
-
-		s.messages <- data
 	}
-
-	return nil
 }
@@ -0,0 +1,176 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"math"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	// Positions with at most this many empty cells are searched all the way to the end of the game.
+	minimaxFullSearchEmpty = 10
+	// On larger boards search as deep as possible while visiting roughly this many positions.
+	minimaxNodeBudget = 20000
+	// Hard limit on the positions visited for a single move, positions beyond it are scored as even.
+	minimaxMaxNodes = 200000
+	// With more legal moves than this, only moves next to an existing mark are considered.
+	minimaxMaxBranching = 16
+
+	minimaxWinScore = 1000
+)
+
+// Plays using minimax with alpha-beta pruning, driven entirely by the game rules so it works for every game. Plays
+// perfectly on boards small enough to search to the end of the game, and looks a few moves ahead on larger ones.
+// Also used whenever the TF model server can't be reached, so AI matches keep working without the `tf` service.
+type minimaxAIPlayer struct{}
+
+func (p *minimaxAIPlayer) Name() string {
+	return aiPlayerMinimax
+}
+
+func (p *minimaxAIPlayer) Move(ctx context.Context, rules GameRules, dimensions BoardDimensions, board []api.Mark, aiMark api.Mark) (int, error) {
+	search := &minimaxSearch{
+		ctx:        ctx,
+		rules:      rules,
+		dimensions: dimensions,
+	}
+
+	candidates := search.candidates(board, aiMark)
+	if empty := len(emptyPositions(board)); empty <= minimaxFullSearchEmpty {
+		search.maxDepth = empty
+	} else {
+		// Each extra level multiplies the work by roughly the number of moves available.
+		search.maxDepth = 1
+		for nodes := len(candidates); nodes*len(candidates) <= minimaxNodeBudget && len(candidates) > 1; nodes *= len(candidates) {
+			search.maxDepth++
+		}
+	}
+
+	bestScore := math.MinInt
+	bestPos := -1
+	for _, pos := range candidates {
+		score := search.score(board, aiMark, aiMark, pos, 1, math.MinInt+1, math.MaxInt)
+		if score > bestScore {
+			bestScore = score
+			bestPos = pos
+		}
+		if ctx.Err() != nil {
+			// Out of time, go with the best move found so far.
+			break
+		}
+	}
+
+	if bestPos < 0 {
+		return -1, errNoMovesAvailable
+	}
+
+	return bestPos, nil
+}
+
+type minimaxSearch struct {
+	ctx        context.Context
+	rules      GameRules
+	dimensions BoardDimensions
+	maxDepth   int
+	nodes      int
+}
+
+// Score a move from the point of view of the given player. Faster wins and slower losses score higher, and
+// positions beyond the search depth are scored as even.
+func (ms *minimaxSearch) score(board []api.Mark, player, mark api.Mark, pos, depth, alpha, beta int) int {
+	ms.nodes++
+	b := make([]api.Mark, len(board))
+	copy(b, board)
+	landed, err := ms.rules.ApplyMove(ms.dimensions, b, mark, pos)
+	if err != nil {
+		return math.MinInt + 1
+	}
+
+	if done, winner, _ := ms.rules.Result(ms.dimensions, b, landed); done {
+		switch winner {
+		case player:
+			return minimaxWinScore - depth
+		case api.Mark_MARK_UNSPECIFIED:
+			return 0
+		default:
+			return depth - minimaxWinScore
+		}
+	}
+	if depth >= ms.maxDepth || ms.nodes >= minimaxMaxNodes || ms.ctx.Err() != nil {
+		return 0
+	}
+
+	// Some games let a player move again if their opponent has to pass, so the score is always kept from the point
+	// of view of the same player: they pick the best score, and their opponent the worst.
+	next := ms.rules.NextTurn(ms.dimensions, b, mark)
+	maximising := next == player
+	best := math.MaxInt
+	if maximising {
+		best = math.MinInt + 1
+	}
+	for _, nextPos := range ms.candidates(b, next) {
+		score := ms.score(b, player, next, nextPos, depth+1, alpha, beta)
+		if maximising {
+			best = max(best, score)
+			alpha = max(alpha, score)
+		} else {
+			best = min(best, score)
+			beta = min(beta, score)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+
+	return best
+}
+
+// The moves worth considering. When there are many legal moves only those next to an existing mark are considered,
+// to keep the search fast on large boards.
+func (ms *minimaxSearch) candidates(board []api.Mark, mark api.Mark) []int {
+	moves := ms.rules.LegalMoves(ms.dimensions, board, mark)
+	if len(moves) <= minimaxMaxBranching {
+		return moves
+	}
+
+	d := ms.dimensions
+	candidates := make([]int, 0, len(moves))
+	for _, pos := range moves {
+		x, y := pos%d.Width, pos/d.Width
+	neighbours:
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if d.contains(x+dx, y+dy) && board[(y+dy)*d.Width+x+dx] != api.Mark_MARK_UNSPECIFIED {
+					candidates = append(candidates, pos)
+					break neighbours
+				}
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		// Nothing has been played yet, open in the centre of the board.
+		centre := (d.Height/2)*d.Width + d.Width/2
+		for _, pos := range moves {
+			if pos == centre {
+				return []int{centre}
+			}
+		}
+		return moves
+	}
+	return candidates
+}
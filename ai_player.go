@@ -0,0 +1,103 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	aiPlayerRandom  = "random"
+	aiPlayerTF      = "tf"
+	aiPlayerNoisy   = "noisy"
+	aiPlayerMinimax = "minimax"
+)
+
+var errNoMovesAvailable = errors.New("no moves available")
+
+// The AI player used for each difficulty level.
+var aiDifficultyPlayers = map[api.Difficulty]string{
+	api.Difficulty_DIFFICULTY_UNSPECIFIED: aiPlayerTF,
+	api.Difficulty_DIFFICULTY_EASY:        aiPlayerRandom,
+	api.Difficulty_DIFFICULTY_MEDIUM:      aiPlayerNoisy,
+	api.Difficulty_DIFFICULTY_HARD:        aiPlayerTF,
+	api.Difficulty_DIFFICULTY_PERFECT:     aiPlayerMinimax,
+}
+
+// AIPlayer picks the moves made by the AI opponent.
+type AIPlayer interface {
+	// Name the player is registered under.
+	Name() string
+	// Move returns the board position the AI wants to play, according to the rules of the game.
+	Move(ctx context.Context, rules GameRules, dimensions BoardDimensions, board []api.Mark, aiMark api.Mark) (int, error)
+}
+
+// Build all available AI players, keyed by name.
+func newAIPlayers(logger runtime.Logger, nk runtime.NakamaModule, tfServingAddress string) map[string]AIPlayer {
+	model := newTFModel(logger, nk, tfServingAddress, aiRequestTimeout)
+	minimax := &minimaxAIPlayer{}
+
+	players := []AIPlayer{
+		&randomAIPlayer{},
+		minimax,
+		&tfAIPlayer{model: model, fallback: minimax},
+		&noisyAIPlayer{model: model, fallback: minimax},
+	}
+
+	byName := make(map[string]AIPlayer, len(players))
+	for _, player := range players {
+		byName[player.Name()] = player
+	}
+	return byName
+}
+
+// Find the AI player for the given difficulty, falling back to the default if it's unknown.
+func (m *MatchHandler) resolveAIPlayer(difficulty api.Difficulty) AIPlayer {
+	name, ok := aiDifficultyPlayers[difficulty]
+	if !ok {
+		name = aiDifficultyPlayers[api.Difficulty_DIFFICULTY_UNSPECIFIED]
+	}
+	return m.aiPlayers[name]
+}
+
+// Plays a random legal move.
+type randomAIPlayer struct{}
+
+func (p *randomAIPlayer) Name() string {
+	return aiPlayerRandom
+}
+
+func (p *randomAIPlayer) Move(ctx context.Context, rules GameRules, dimensions BoardDimensions, board []api.Mark, aiMark api.Mark) (int, error) {
+	positions := rules.LegalMoves(dimensions, board, aiMark)
+	if len(positions) == 0 {
+		return -1, errNoMovesAvailable
+	}
+	return positions[rand.Intn(len(positions))], nil
+}
+
+func emptyPositions(board []api.Mark) []int {
+	positions := make([]int, 0, len(board))
+	for position, mark := range board {
+		if mark == api.Mark_MARK_UNSPECIFIED {
+			positions = append(positions, position)
+		}
+	}
+	return positions
+}
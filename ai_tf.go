@@ -0,0 +1,271 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+// How long to rely on the fallback player before trying the TF model server again.
+const aiTFRetryInterval = 10 * time.Second
+
+var errTFUnavailable = errors.New("TF model server unavailable")
+
+type cell [2]int
+type row [3]cell
+type board [3]row
+
+type tfRequest struct {
+	Instances [1]board `json:"instances"`
+}
+
+type tfResponse struct {
+	Predictions [][]float64 `json:"predictions"`
+}
+
+// A client for the TF Serving model, shared by all players that rely on its predictions. It keeps track of
+// whether the model server is reachable so players can fail over without waiting on a timeout every move.
+type tfModel struct {
+	logger  runtime.Logger
+	nk      runtime.NakamaModule
+	client  *http.Client
+	address string
+
+	// Set while the TF model server is considered unreachable, requests are skipped until retryAt.
+	mu      sync.Mutex
+	down    bool
+	retryAt time.Time
+}
+
+func newTFModel(logger runtime.Logger, nk runtime.NakamaModule, address string, timeout time.Duration) *tfModel {
+	return &tfModel{
+		logger:  logger,
+		nk:      nk,
+		client:  &http.Client{Timeout: timeout},
+		address: address,
+	}
+}
+
+// Predict returns the model's score for each board position.
+func (m *tfModel) Predict(ctx context.Context, marks []api.Mark, aiMark api.Mark) ([]float64, error) {
+	if !m.available() {
+		return nil, errTFUnavailable
+	}
+
+	predictions, err := m.predict(ctx, marks, aiMark)
+	if err != nil {
+		m.failed(err)
+		return nil, err
+	}
+
+	m.succeeded()
+	return predictions, nil
+}
+
+func (m *tfModel) predict(ctx context.Context, marks []api.Mark, aiMark api.Mark) ([]float64, error) {
+	// Convert board state into expected model format
+	b := board{}
+
+	for i, mark := range marks {
+		rowIdx := i / 3
+		cellIdx := i % 3
+
+		switch mark {
+		case aiMark: // AI
+			b[rowIdx][cellIdx] = cell{1, 0}
+		case api.Mark_MARK_UNSPECIFIED:
+			b[rowIdx][cellIdx] = cell{0, 0}
+		default: // Player
+			b[rowIdx][cellIdx] = cell{0, 1}
+		}
+	}
+
+	// Send the vectors to TF
+	req := tfRequest{Instances: [1]board{b}}
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal TF request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, m.address, bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TF request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("received unexpected TF response status: %d", resp.StatusCode)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make TF request: %w", err)
+	}
+
+	// Convert response into message
+	predictions := tfResponse{}
+	if err := json.Unmarshal(respBody, &predictions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal TF response: %w", err)
+	}
+
+	if len(predictions.Predictions) != 1 || len(predictions.Predictions[0]) != len(marks) {
+		return nil, fmt.Errorf("received unexpected TF response: %v", predictions.Predictions)
+	}
+
+	return predictions.Predictions[0], nil
+}
+
+// Check if the TF model server should be used. Once the retry interval has passed a single request is let through
+// to probe whether it has recovered.
+func (m *tfModel) available() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.down {
+		return true
+	}
+	if time.Now().Before(m.retryAt) {
+		return false
+	}
+	m.retryAt = time.Now().Add(aiTFRetryInterval)
+	return true
+}
+
+func (m *tfModel) failed(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.down {
+		m.logger.Warn("TF model server unavailable, AI players falling back: %v", err)
+	}
+	m.down = true
+	m.retryAt = time.Now().Add(aiTFRetryInterval)
+}
+
+func (m *tfModel) succeeded() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.down {
+		m.logger.Info("TF model server recovered")
+	}
+	m.down = false
+}
+
+// The model is only trained on classic 3x3 tic-tac-toe.
+func tfModelSupports(rules GameRules, dimensions BoardDimensions) bool {
+	return rules.Name() == moduleName && dimensions == ticTacToeDimensions
+}
+
+// Always plays the model's highest scoring available position.
+type tfAIPlayer struct {
+	model    *tfModel
+	fallback AIPlayer
+}
+
+func (p *tfAIPlayer) Name() string {
+	return aiPlayerTF
+}
+
+func (p *tfAIPlayer) Move(ctx context.Context, rules GameRules, dimensions BoardDimensions, board []api.Mark, aiMark api.Mark) (int, error) {
+	if !tfModelSupports(rules, dimensions) {
+		return p.fallback.Move(ctx, rules, dimensions, board, aiMark)
+	}
+
+	predictions, err := p.model.Predict(ctx, board, aiMark)
+	if err != nil {
+		p.model.nk.MetricsCounterAdd(metricAIFallbackMoves, map[string]string{"player": p.Name()}, 1)
+		return p.fallback.Move(ctx, rules, dimensions, board, aiMark)
+	}
+
+	// Find the available position with the highest predicted value
+	maxVal := math.Inf(-1)
+	aiMovePos := -1
+	for _, position := range emptyPositions(board) {
+		if predictions[position] > maxVal {
+			maxVal = predictions[position]
+			aiMovePos = position
+		}
+	}
+	if aiMovePos < 0 {
+		return -1, errNoMovesAvailable
+	}
+
+	return aiMovePos, nil
+}
+
+// Picks an available position at random, weighted by the model's softmax predictions. Usually plays well, but
+// occasionally makes a weaker move.
+type noisyAIPlayer struct {
+	model    *tfModel
+	fallback AIPlayer
+}
+
+func (p *noisyAIPlayer) Name() string {
+	return aiPlayerNoisy
+}
+
+func (p *noisyAIPlayer) Move(ctx context.Context, rules GameRules, dimensions BoardDimensions, board []api.Mark, aiMark api.Mark) (int, error) {
+	if !tfModelSupports(rules, dimensions) {
+		return p.fallback.Move(ctx, rules, dimensions, board, aiMark)
+	}
+
+	predictions, err := p.model.Predict(ctx, board, aiMark)
+	if err != nil {
+		p.model.nk.MetricsCounterAdd(metricAIFallbackMoves, map[string]string{"player": p.Name()}, 1)
+		return p.fallback.Move(ctx, rules, dimensions, board, aiMark)
+	}
+
+	positions := emptyPositions(board)
+	if len(positions) == 0 {
+		return -1, errNoMovesAvailable
+	}
+
+	total := 0.0
+	for _, position := range positions {
+		total += math.Max(predictions[position], 0)
+	}
+	if total <= 0 {
+		return positions[rand.Intn(len(positions))], nil
+	}
+
+	target := rand.Float64() * total
+	for _, position := range positions {
+		target -= math.Max(predictions[position], 0)
+		if target <= 0 {
+			return position, nil
+		}
+	}
+	return positions[len(positions)-1], nil
+}
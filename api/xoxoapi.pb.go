@@ -109,29 +109,65 @@ const (
 	OpCode_OPCODE_OPPONENT_LEFT OpCode = 6
 	// Invite AI player to join instead of the opponent who left the game.
 	OpCode_OPCODE_INVITE_AI OpCode = 7
+	// A series of rounds has been decided, and the match is about to end.
+	OpCode_OPCODE_SERIES_DONE OpCode = 8
+	// The AI player has joined the match as the opponent.
+	OpCode_OPCODE_AI_JOINED OpCode = 9
+	// A player asks for another round, once the last one is done.
+	OpCode_OPCODE_REMATCH_REQUEST OpCode = 10
+	// A player agrees to another round.
+	OpCode_OPCODE_REMATCH_ACCEPT OpCode = 11
+	// A player doesn't want another round, or the vote timed out. The match ends.
+	OpCode_OPCODE_REMATCH_DECLINE OpCode = 12
+	// A player gives up the round, and their opponent wins it.
+	OpCode_OPCODE_RESIGN OpCode = 13
+	// A player offers their opponent a draw.
+	OpCode_OPCODE_DRAW_OFFER OpCode = 14
+	// A player accepts or declines the draw their opponent offered.
+	OpCode_OPCODE_DRAW_RESPONSE OpCode = 15
+	// The player to move has disconnected, and their turn clock is paused until they return or run out of time to.
+	OpCode_OPCODE_PAUSED OpCode = 16
 )
 
 // Enum value maps for OpCode.
 var (
 	OpCode_name = map[int32]string{
-		0: "OPCODE_UNSPECIFIED",
-		1: "OPCODE_START",
-		2: "OPCODE_UPDATE",
-		3: "OPCODE_DONE",
-		4: "OPCODE_MOVE",
-		5: "OPCODE_REJECTED",
-		6: "OPCODE_OPPONENT_LEFT",
-		7: "OPCODE_INVITE_AI",
+		0:  "OPCODE_UNSPECIFIED",
+		1:  "OPCODE_START",
+		2:  "OPCODE_UPDATE",
+		3:  "OPCODE_DONE",
+		4:  "OPCODE_MOVE",
+		5:  "OPCODE_REJECTED",
+		6:  "OPCODE_OPPONENT_LEFT",
+		7:  "OPCODE_INVITE_AI",
+		8:  "OPCODE_SERIES_DONE",
+		9:  "OPCODE_AI_JOINED",
+		10: "OPCODE_REMATCH_REQUEST",
+		11: "OPCODE_REMATCH_ACCEPT",
+		12: "OPCODE_REMATCH_DECLINE",
+		13: "OPCODE_RESIGN",
+		14: "OPCODE_DRAW_OFFER",
+		15: "OPCODE_DRAW_RESPONSE",
+		16: "OPCODE_PAUSED",
 	}
 	OpCode_value = map[string]int32{
-		"OPCODE_UNSPECIFIED":   0,
-		"OPCODE_START":         1,
-		"OPCODE_UPDATE":        2,
-		"OPCODE_DONE":          3,
-		"OPCODE_MOVE":          4,
-		"OPCODE_REJECTED":      5,
-		"OPCODE_OPPONENT_LEFT": 6,
-		"OPCODE_INVITE_AI":     7,
+		"OPCODE_UNSPECIFIED":     0,
+		"OPCODE_START":           1,
+		"OPCODE_UPDATE":          2,
+		"OPCODE_DONE":            3,
+		"OPCODE_MOVE":            4,
+		"OPCODE_REJECTED":        5,
+		"OPCODE_OPPONENT_LEFT":   6,
+		"OPCODE_INVITE_AI":       7,
+		"OPCODE_SERIES_DONE":     8,
+		"OPCODE_AI_JOINED":       9,
+		"OPCODE_REMATCH_REQUEST": 10,
+		"OPCODE_REMATCH_ACCEPT":  11,
+		"OPCODE_REMATCH_DECLINE": 12,
+		"OPCODE_RESIGN":          13,
+		"OPCODE_DRAW_OFFER":      14,
+		"OPCODE_DRAW_RESPONSE":   15,
+		"OPCODE_PAUSED":          16,
 	}
 )
 
@@ -162,6 +198,193 @@ func (OpCode) EnumDescriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{1}
 }
 
+// The difficulty levels available for the AI opponent.
+type Difficulty int32
+
+const (
+	// No difficulty specified. Treated as hard.
+	Difficulty_DIFFICULTY_UNSPECIFIED Difficulty = 0
+	// AI plays random moves.
+	Difficulty_DIFFICULTY_EASY Difficulty = 1
+	// AI usually plays well, but sometimes picks a weaker move.
+	Difficulty_DIFFICULTY_MEDIUM Difficulty = 2
+	// AI always plays the model's best prediction.
+	Difficulty_DIFFICULTY_HARD Difficulty = 3
+	// AI plays perfectly and never loses.
+	Difficulty_DIFFICULTY_PERFECT Difficulty = 4
+)
+
+// Enum value maps for Difficulty.
+var (
+	Difficulty_name = map[int32]string{
+		0: "DIFFICULTY_UNSPECIFIED",
+		1: "DIFFICULTY_EASY",
+		2: "DIFFICULTY_MEDIUM",
+		3: "DIFFICULTY_HARD",
+		4: "DIFFICULTY_PERFECT",
+	}
+	Difficulty_value = map[string]int32{
+		"DIFFICULTY_UNSPECIFIED": 0,
+		"DIFFICULTY_EASY":        1,
+		"DIFFICULTY_MEDIUM":      2,
+		"DIFFICULTY_HARD":        3,
+		"DIFFICULTY_PERFECT":     4,
+	}
+)
+
+func (x Difficulty) Enum() *Difficulty {
+	p := new(Difficulty)
+	*p = x
+	return p
+}
+
+func (x Difficulty) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Difficulty) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[2].Descriptor()
+}
+
+func (Difficulty) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[2]
+}
+
+func (x Difficulty) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Difficulty.Descriptor instead.
+func (Difficulty) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{2}
+}
+
+// How a round ended.
+type DoneReason int32
+
+const (
+	// No reason specified. Unused.
+	DoneReason_DONE_REASON_UNSPECIFIED DoneReason = 0
+	// A player completed a winning line, or had the most marks when the game ended.
+	DoneReason_DONE_REASON_WIN DoneReason = 1
+	// No more moves were possible, and nobody won.
+	DoneReason_DONE_REASON_TIE DoneReason = 2
+	// A player ran out of time to make their move.
+	DoneReason_DONE_REASON_TIMEOUT DoneReason = 3
+	// A player resigned.
+	DoneReason_DONE_REASON_RESIGN DoneReason = 4
+	// The players agreed to a draw.
+	DoneReason_DONE_REASON_AGREED_DRAW DoneReason = 5
+	// A player left the match and didn't return in time to make their move.
+	DoneReason_DONE_REASON_OPPONENT_LEFT DoneReason = 6
+)
+
+// Enum value maps for DoneReason.
+var (
+	DoneReason_name = map[int32]string{
+		0: "DONE_REASON_UNSPECIFIED",
+		1: "DONE_REASON_WIN",
+		2: "DONE_REASON_TIE",
+		3: "DONE_REASON_TIMEOUT",
+		4: "DONE_REASON_RESIGN",
+		5: "DONE_REASON_AGREED_DRAW",
+		6: "DONE_REASON_OPPONENT_LEFT",
+	}
+	DoneReason_value = map[string]int32{
+		"DONE_REASON_UNSPECIFIED":   0,
+		"DONE_REASON_WIN":           1,
+		"DONE_REASON_TIE":           2,
+		"DONE_REASON_TIMEOUT":       3,
+		"DONE_REASON_RESIGN":        4,
+		"DONE_REASON_AGREED_DRAW":   5,
+		"DONE_REASON_OPPONENT_LEFT": 6,
+	}
+)
+
+func (x DoneReason) Enum() *DoneReason {
+	p := new(DoneReason)
+	*p = x
+	return p
+}
+
+func (x DoneReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DoneReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[3].Descriptor()
+}
+
+func (DoneReason) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[3]
+}
+
+func (x DoneReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DoneReason.Descriptor instead.
+func (DoneReason) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{3}
+}
+
+// The outcome of a round for one of its players.
+type RoundResult int32
+
+const (
+	// No result specified. Unused.
+	RoundResult_ROUND_RESULT_UNSPECIFIED RoundResult = 0
+	// The player won the round.
+	RoundResult_ROUND_RESULT_WIN RoundResult = 1
+	// The player lost the round.
+	RoundResult_ROUND_RESULT_LOSS RoundResult = 2
+	// The round was a draw.
+	RoundResult_ROUND_RESULT_DRAW RoundResult = 3
+)
+
+// Enum value maps for RoundResult.
+var (
+	RoundResult_name = map[int32]string{
+		0: "ROUND_RESULT_UNSPECIFIED",
+		1: "ROUND_RESULT_WIN",
+		2: "ROUND_RESULT_LOSS",
+		3: "ROUND_RESULT_DRAW",
+	}
+	RoundResult_value = map[string]int32{
+		"ROUND_RESULT_UNSPECIFIED": 0,
+		"ROUND_RESULT_WIN":         1,
+		"ROUND_RESULT_LOSS":        2,
+		"ROUND_RESULT_DRAW":        3,
+	}
+)
+
+func (x RoundResult) Enum() *RoundResult {
+	p := new(RoundResult)
+	*p = x
+	return p
+}
+
+func (x RoundResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoundResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_xoxoapi_proto_enumTypes[4].Descriptor()
+}
+
+func (RoundResult) Type() protoreflect.EnumType {
+	return &file_xoxoapi_proto_enumTypes[4]
+}
+
+func (x RoundResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoundResult.Descriptor instead.
+func (RoundResult) EnumDescriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{4}
+}
+
 // Message data sent by server to clients representing a new game round starting.
 type Start struct {
 	state         protoimpl.MessageState
@@ -176,6 +399,20 @@ type Start struct {
 	Mark Mark `protobuf:"varint,3,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
 	// The deadline time by which the player must submit their move, or forfeit.
 	Deadline int64 `protobuf:"varint,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// The number of columns on the board.
+	Width int32 `protobuf:"varint,5,opt,name=width,proto3" json:"width,omitempty"`
+	// The number of rows on the board.
+	Height int32 `protobuf:"varint,6,opt,name=height,proto3" json:"height,omitempty"`
+	// The number of marks in a row needed to win.
+	WinLength int32 `protobuf:"varint,7,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// Rounds won so far by each user ID, if the match is a series.
+	SeriesScore map[string]int32 `protobuf:"bytes,8,rep,name=series_score,json=seriesScore,proto3" json:"series_score,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The number of rounds in the series, or 0 if the match is not a series.
+	BestOf int32 `protobuf:"varint,9,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// The number of this round in the match, starting from 1. Used to look up the round's replay.
+	Round int32 `protobuf:"varint,10,opt,name=round,proto3" json:"round,omitempty"`
+	// Time left in each user ID's time bank in milliseconds, if the match uses a chess clock.
+	TimeBanks map[string]int64 `protobuf:"bytes,11,rep,name=time_banks,json=timeBanks,proto3" json:"time_banks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *Start) Reset() {
@@ -238,6 +475,55 @@ func (x *Start) GetDeadline() int64 {
 	return 0
 }
 
+func (x *Start) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Start) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Start) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *Start) GetSeriesScore() map[string]int32 {
+	if x != nil {
+		return x.SeriesScore
+	}
+	return nil
+}
+
+func (x *Start) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *Start) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Start) GetTimeBanks() map[string]int64 {
+	if x != nil {
+		return x.TimeBanks
+	}
+	return nil
+}
+
 // A game state update sent by the server to clients.
 type Update struct {
 	state         protoimpl.MessageState
@@ -250,6 +536,17 @@ type Update struct {
 	Mark Mark `protobuf:"varint,2,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
 	// The deadline time by which the player must submit their move, or forfeit.
 	Deadline int64 `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// The number of columns on the board.
+	Width int32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	// The number of rows on the board.
+	Height int32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// The number of marks in a row needed to win.
+	WinLength int32 `protobuf:"varint,6,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// The assignments of the marks to players for this round. Only sent to users joining a round already in progress.
+	Marks map[string]Mark `protobuf:"bytes,7,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=api.Mark"`
+	// Time left in each user ID's time bank in milliseconds, if the match uses a chess clock. The player to move loses
+	// the round when theirs runs out, at the deadline.
+	TimeBanks map[string]int64 `protobuf:"bytes,8,rep,name=time_banks,json=timeBanks,proto3" json:"time_banks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *Update) Reset() {
@@ -305,6 +602,41 @@ func (x *Update) GetDeadline() int64 {
 	return 0
 }
 
+func (x *Update) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Update) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Update) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *Update) GetMarks() map[string]Mark {
+	if x != nil {
+		return x.Marks
+	}
+	return nil
+}
+
+func (x *Update) GetTimeBanks() map[string]int64 {
+	if x != nil {
+		return x.TimeBanks
+	}
+	return nil
+}
+
 // Complete game round with winner announcement.
 type Done struct {
 	state         protoimpl.MessageState
@@ -318,8 +650,14 @@ type Done struct {
 	// Winner board positions, if any. Used to display the row, column, or diagonal that won the game.
 	// May be empty if it's a draw or the winner is by forfeit.
 	WinnerPositions []int32 `protobuf:"varint,3,rep,packed,name=winner_positions,json=winnerPositions,proto3" json:"winner_positions,omitempty"`
-	// Next round start time.
+	// Next round start time. If the match isn't a series, the time voting for a rematch closes instead.
 	NextGameStart int64 `protobuf:"varint,4,opt,name=next_game_start,json=nextGameStart,proto3" json:"next_game_start,omitempty"`
+	// Rounds won so far by each user ID, including this one, if the match is a series.
+	SeriesScore map[string]int32 `protobuf:"bytes,5,rep,name=series_score,json=seriesScore,proto3" json:"series_score,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The number of this round in the match, starting from 1. Used to look up the round's replay.
+	Round int32 `protobuf:"varint,6,opt,name=round,proto3" json:"round,omitempty"`
+	// How the round ended.
+	Reason DoneReason `protobuf:"varint,7,opt,name=reason,proto3,enum=api.DoneReason" json:"reason,omitempty"`
 }
 
 func (x *Done) Reset() {
@@ -382,18 +720,43 @@ func (x *Done) GetNextGameStart() int64 {
 	return 0
 }
 
-// A player intends to make a move.
-type Move struct {
+func (x *Done) GetSeriesScore() map[string]int32 {
+	if x != nil {
+		return x.SeriesScore
+	}
+	return nil
+}
+
+func (x *Done) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Done) GetReason() DoneReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DoneReason_DONE_REASON_UNSPECIFIED
+}
+
+// Final result of a series, sent after the round that decided it.
+type SeriesDone struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The position the player wants to place their mark in.
-	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	// Rounds won by each user ID.
+	SeriesScore map[string]int32 `protobuf:"bytes,1,rep,name=series_score,json=seriesScore,proto3" json:"series_score,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The user ID that won the series. Empty if the series was drawn, or both players left while the score was level.
+	Winner string `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	// The number of rounds in the series.
+	BestOf int32 `protobuf:"varint,3,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
 }
 
-func (x *Move) Reset() {
-	*x = Move{}
+func (x *SeriesDone) Reset() {
+	*x = SeriesDone{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -401,13 +764,13 @@ func (x *Move) Reset() {
 	}
 }
 
-func (x *Move) String() string {
+func (x *SeriesDone) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Move) ProtoMessage() {}
+func (*SeriesDone) ProtoMessage() {}
 
-func (x *Move) ProtoReflect() protoreflect.Message {
+func (x *SeriesDone) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,32 +782,47 @@ func (x *Move) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Move.ProtoReflect.Descriptor instead.
-func (*Move) Descriptor() ([]byte, []int) {
+// Deprecated: Use SeriesDone.ProtoReflect.Descriptor instead.
+func (*SeriesDone) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Move) GetPosition() int32 {
+func (x *SeriesDone) GetSeriesScore() map[string]int32 {
 	if x != nil {
-		return x.Position
+		return x.SeriesScore
+	}
+	return nil
+}
+
+func (x *SeriesDone) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+func (x *SeriesDone) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
 	}
 	return 0
 }
 
-// Payload for an RPC request to find a match.
-type RpcFindMatchRequest struct {
+// A draw offer or the response to one. Clients only need to set accept when responding, the server fills in the rest
+// when relaying it to everyone in the match.
+type DrawResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// User can choose a fast or normal speed match.
-	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
-	// User can choose whether to play with AI
-	Ai bool `protobuf:"varint,2,opt,name=ai,proto3" json:"ai,omitempty"`
+	// The user ID that offered or responded to the draw.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// True if the draw was accepted.
+	Accept bool `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
 }
 
-func (x *RpcFindMatchRequest) Reset() {
-	*x = RpcFindMatchRequest{}
+func (x *DrawResponse) Reset() {
+	*x = DrawResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -452,13 +830,13 @@ func (x *RpcFindMatchRequest) Reset() {
 	}
 }
 
-func (x *RpcFindMatchRequest) String() string {
+func (x *DrawResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RpcFindMatchRequest) ProtoMessage() {}
+func (*DrawResponse) ProtoMessage() {}
 
-func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
+func (x *DrawResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -470,37 +848,37 @@ func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RpcFindMatchRequest.ProtoReflect.Descriptor instead.
-func (*RpcFindMatchRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DrawResponse.ProtoReflect.Descriptor instead.
+func (*DrawResponse) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *RpcFindMatchRequest) GetFast() bool {
+func (x *DrawResponse) GetUserId() string {
 	if x != nil {
-		return x.Fast
+		return x.UserId
 	}
-	return false
+	return ""
 }
 
-func (x *RpcFindMatchRequest) GetAi() bool {
+func (x *DrawResponse) GetAccept() bool {
 	if x != nil {
-		return x.Ai
+		return x.Accept
 	}
 	return false
 }
 
-// Payload for an RPC response containing match IDs the user can join.
-type RpcFindMatchResponse struct {
+// A player's vote for or against a rematch, sent by the server to all clients.
+type Rematch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// One or more matches that fit the user's request.
-	MatchIds []string `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+	// The user ID that voted. Empty if the vote timed out.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 }
 
-func (x *RpcFindMatchResponse) Reset() {
-	*x = RpcFindMatchResponse{}
+func (x *Rematch) Reset() {
+	*x = Rematch{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_xoxoapi_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,13 +886,13 @@ func (x *RpcFindMatchResponse) Reset() {
 	}
 }
 
-func (x *RpcFindMatchResponse) String() string {
+func (x *Rematch) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RpcFindMatchResponse) ProtoMessage() {}
+func (*Rematch) ProtoMessage() {}
 
-func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
+func (x *Rematch) ProtoReflect() protoreflect.Message {
 	mi := &file_xoxoapi_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -526,132 +904,3999 @@ func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RpcFindMatchResponse.ProtoReflect.Descriptor instead.
-func (*RpcFindMatchResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Rematch.ProtoReflect.Descriptor instead.
+func (*Rematch) Descriptor() ([]byte, []int) {
 	return file_xoxoapi_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *RpcFindMatchResponse) GetMatchIds() []string {
+func (x *Rematch) GetUserId() string {
 	if x != nil {
-		return x.MatchIds
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-var File_xoxoapi_proto protoreflect.FileDescriptor
+// The turn clock is paused while a disconnected player has a chance to reconnect.
+type Pause struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_xoxoapi_proto_rawDesc = []byte{
-	0x0a, 0x0d, 0x78, 0x6f, 0x78, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x03, 0x61, 0x70, 0x69, 0x22, 0xd5, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f,
-	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
-	0x2b, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x04,
-	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
-	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x1a, 0x43, 0x0a, 0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72,
-	0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x64, 0x0a, 0x06,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
-	0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
-	0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
-	0x6e, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62,
-	0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x06,
-	0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12,
-	0x29, 0x0a, 0x10, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x65,
-	0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
-	0x78, 0x74, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x22, 0x22, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f,
-	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x13, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e,
-	0x64, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73,
-	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x61,
-	0x69, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74, 0x63,
-	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x74,
-	0x63, 0x68, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x49, 0x64, 0x73, 0x2a, 0x34, 0x0a, 0x04, 0x4d, 0x61, 0x72, 0x6b, 0x12, 0x14,
-	0x0a, 0x10, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x58, 0x10, 0x01,
-	0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x4f, 0x10, 0x02, 0x2a, 0xac, 0x01, 0x0a,
-	0x06, 0x4f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x50, 0x43, 0x4f, 0x44,
-	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x10, 0x0a, 0x0c, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10,
-	0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41,
-	0x54, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x44,
-	0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x4d, 0x4f, 0x56, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45,
-	0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x18, 0x0a, 0x14, 0x4f,
-	0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x50, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x4c,
-	0x45, 0x46, 0x54, 0x10, 0x06, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
-	0x49, 0x4e, 0x56, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x49, 0x10, 0x07, 0x42, 0x33, 0x5a, 0x31, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x65, 0x72, 0x6f, 0x69, 0x63,
-	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6e, 0x61, 0x6b, 0x61, 0x6d, 0x61, 0x2d, 0x70, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x2f, 0x61, 0x70, 0x69,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// The user ID that disconnected.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Seconds since the Unix epoch when the player forfeits the round if they haven't returned.
+	Deadline int64 `protobuf:"varint,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
 }
 
-var (
-	file_xoxoapi_proto_rawDescOnce sync.Once
-	file_xoxoapi_proto_rawDescData = file_xoxoapi_proto_rawDesc
-)
+func (x *Pause) Reset() {
+	*x = Pause{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_xoxoapi_proto_rawDescGZIP() []byte {
-	file_xoxoapi_proto_rawDescOnce.Do(func() {
-		file_xoxoapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_xoxoapi_proto_rawDescData)
-	})
-	return file_xoxoapi_proto_rawDescData
+func (x *Pause) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_xoxoapi_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_xoxoapi_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_xoxoapi_proto_goTypes = []interface{}{
-	(Mark)(0),                    // 0: api.Mark
-	(OpCode)(0),                  // 1: api.OpCode
-	(*Start)(nil),                // 2: api.Start
-	(*Update)(nil),               // 3: api.Update
-	(*Done)(nil),                 // 4: api.Done
-	(*Move)(nil),                 // 5: api.Move
-	(*RpcFindMatchRequest)(nil),  // 6: api.RpcFindMatchRequest
-	(*RpcFindMatchResponse)(nil), // 7: api.RpcFindMatchResponse
-	nil,                          // 8: api.Start.MarksEntry
+func (*Pause) ProtoMessage() {}
+
+func (x *Pause) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_xoxoapi_proto_depIdxs = []int32{
-	0, // 0: api.Start.board:type_name -> api.Mark
-	8, // 1: api.Start.marks:type_name -> api.Start.MarksEntry
-	0, // 2: api.Start.mark:type_name -> api.Mark
-	0, // 3: api.Update.board:type_name -> api.Mark
-	0, // 4: api.Update.mark:type_name -> api.Mark
-	0, // 5: api.Done.board:type_name -> api.Mark
-	0, // 6: api.Done.winner:type_name -> api.Mark
-	0, // 7: api.Start.MarksEntry.value:type_name -> api.Mark
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+
+// Deprecated: Use Pause.ProtoReflect.Descriptor instead.
+func (*Pause) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{6}
 }
 
-func init() { file_xoxoapi_proto_init() }
-func file_xoxoapi_proto_init() {
-	if File_xoxoapi_proto != nil {
-		return
+func (x *Pause) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_xoxoapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Start); i {
-			case 0:
+	return ""
+}
+
+func (x *Pause) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+// A player intends to make a move.
+type Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The position the player wants to place their mark in, numbered row by row from the top left corner.
+	Position int32 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Move) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// A move accepted by the server during a round.
+type ReplayMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user ID that made the move.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The mark that was played.
+	Mark Mark `protobuf:"varint,2,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
+	// The position the mark ended up in.
+	Position int32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	// The match tick the move was made on.
+	Tick int64 `protobuf:"varint,4,opt,name=tick,proto3" json:"tick,omitempty"`
+	// The time the move was made, in UNIX time.
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *ReplayMove) Reset() {
+	*x = ReplayMove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplayMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayMove) ProtoMessage() {}
+
+func (x *ReplayMove) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayMove.ProtoReflect.Descriptor instead.
+func (*ReplayMove) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplayMove) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReplayMove) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *ReplayMove) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ReplayMove) GetTick() int64 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *ReplayMove) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// The full record of a completed round.
+type Replay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match the round was played in.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The number of the round in the match, starting from 1.
+	Round int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// The game that was played.
+	Game string `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	// The number of columns on the board.
+	Width int32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	// The number of rows on the board.
+	Height int32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// The number of marks in a row needed to win.
+	WinLength int32 `protobuf:"varint,6,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// The assignments of the marks to players for this round.
+	Marks map[string]Mark `protobuf:"bytes,7,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=api.Mark"`
+	// Every accepted move, in the order they were made.
+	Moves []*ReplayMove `protobuf:"bytes,8,rep,name=moves,proto3" json:"moves,omitempty"`
+	// The match tick the round started on.
+	StartTick int64 `protobuf:"varint,9,opt,name=start_tick,json=startTick,proto3" json:"start_tick,omitempty"`
+	// The time the round started, in UNIX time.
+	StartTime int64 `protobuf:"varint,10,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// The match tick the round ended on.
+	EndTick int64 `protobuf:"varint,11,opt,name=end_tick,json=endTick,proto3" json:"end_tick,omitempty"`
+	// The time the round ended, in UNIX time.
+	EndTime int64 `protobuf:"varint,12,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// The final state of the board.
+	Board []Mark `protobuf:"varint,13,rep,packed,name=board,proto3,enum=api.Mark" json:"board,omitempty"`
+	// The winner of the round, if any. Unspecified if it's a draw.
+	Winner Mark `protobuf:"varint,14,opt,name=winner,proto3,enum=api.Mark" json:"winner,omitempty"`
+	// Winner board positions, if any. May be empty if it's a draw or the winner is by forfeit.
+	WinnerPositions []int32 `protobuf:"varint,15,rep,packed,name=winner_positions,json=winnerPositions,proto3" json:"winner_positions,omitempty"`
+	// How the round ended.
+	Reason DoneReason `protobuf:"varint,16,opt,name=reason,proto3,enum=api.DoneReason" json:"reason,omitempty"`
+}
+
+func (x *Replay) Reset() {
+	*x = Replay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Replay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replay) ProtoMessage() {}
+
+func (x *Replay) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replay.ProtoReflect.Descriptor instead.
+func (*Replay) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Replay) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Replay) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *Replay) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *Replay) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Replay) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Replay) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *Replay) GetMarks() map[string]Mark {
+	if x != nil {
+		return x.Marks
+	}
+	return nil
+}
+
+func (x *Replay) GetMoves() []*ReplayMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *Replay) GetStartTick() int64 {
+	if x != nil {
+		return x.StartTick
+	}
+	return 0
+}
+
+func (x *Replay) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Replay) GetEndTick() int64 {
+	if x != nil {
+		return x.EndTick
+	}
+	return 0
+}
+
+func (x *Replay) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Replay) GetBoard() []Mark {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *Replay) GetWinner() Mark {
+	if x != nil {
+		return x.Winner
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *Replay) GetWinnerPositions() []int32 {
+	if x != nil {
+		return x.WinnerPositions
+	}
+	return nil
+}
+
+func (x *Replay) GetReason() DoneReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DoneReason_DONE_REASON_UNSPECIFIED
+}
+
+// Payload for an RPC request to find a match.
+type RpcFindMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User can choose a fast or normal speed match.
+	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
+	// User can choose whether to play with AI
+	Ai bool `protobuf:"varint,2,opt,name=ai,proto3" json:"ai,omitempty"`
+	// User can choose how strong the AI opponent is, if playing with AI.
+	Difficulty Difficulty `protobuf:"varint,3,opt,name=difficulty,proto3,enum=api.Difficulty" json:"difficulty,omitempty"`
+	// User can choose the number of columns on the board. Defaults to the game's usual board.
+	Width int32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	// User can choose the number of rows on the board. Defaults to the game's usual board.
+	Height int32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// User can choose the number of marks in a row needed to win. Defaults to the game's usual rules.
+	WinLength int32 `protobuf:"varint,6,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// User can choose which game to play: "tic-tac-toe", "connect-four" or "reversi". Defaults to "tic-tac-toe".
+	Game string `protobuf:"bytes,7,opt,name=game,proto3" json:"game,omitempty"`
+	// User can choose to play a series, won by whoever wins the majority of this many rounds. Must be odd.
+	// Defaults to 0, where new rounds keep starting until the players leave.
+	BestOf int32 `protobuf:"varint,8,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// User can choose a chess clock, where each player has this many seconds in total for their moves in a round.
+	// Defaults to 0, where every move has a fixed deadline instead.
+	TimeBankSec int32 `protobuf:"varint,9,opt,name=time_bank_sec,json=timeBankSec,proto3" json:"time_bank_sec,omitempty"`
+	// User can choose how many seconds are added to a player's time bank after each of their moves.
+	IncrementSec int32 `protobuf:"varint,10,opt,name=increment_sec,json=incrementSec,proto3" json:"increment_sec,omitempty"`
+}
+
+func (x *RpcFindMatchRequest) Reset() {
+	*x = RpcFindMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcFindMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcFindMatchRequest) ProtoMessage() {}
+
+func (x *RpcFindMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcFindMatchRequest.ProtoReflect.Descriptor instead.
+func (*RpcFindMatchRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RpcFindMatchRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcFindMatchRequest) GetAi() bool {
+	if x != nil {
+		return x.Ai
+	}
+	return false
+}
+
+func (x *RpcFindMatchRequest) GetDifficulty() Difficulty {
+	if x != nil {
+		return x.Difficulty
+	}
+	return Difficulty_DIFFICULTY_UNSPECIFIED
+}
+
+func (x *RpcFindMatchRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *RpcFindMatchRequest) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetTimeBankSec() int32 {
+	if x != nil {
+		return x.TimeBankSec
+	}
+	return 0
+}
+
+func (x *RpcFindMatchRequest) GetIncrementSec() int32 {
+	if x != nil {
+		return x.IncrementSec
+	}
+	return 0
+}
+
+// Payload for an RPC response containing the match the user should join.
+type RpcFindMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The single best match for the user's request, either an existing match or a newly created one.
+	MatchIds []string `protobuf:"bytes,1,rep,name=match_ids,json=matchIds,proto3" json:"match_ids,omitempty"`
+}
+
+func (x *RpcFindMatchResponse) Reset() {
+	*x = RpcFindMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcFindMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcFindMatchResponse) ProtoMessage() {}
+
+func (x *RpcFindMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcFindMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcFindMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RpcFindMatchResponse) GetMatchIds() []string {
+	if x != nil {
+		return x.MatchIds
+	}
+	return nil
+}
+
+// Payload for an RPC request to fetch the replay of a round.
+type RpcGetReplayRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match the round was played in.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The number of the round in the match, starting from 1.
+	Round int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (x *RpcGetReplayRequest) Reset() {
+	*x = RpcGetReplayRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetReplayRequest) ProtoMessage() {}
+
+func (x *RpcGetReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetReplayRequest.ProtoReflect.Descriptor instead.
+func (*RpcGetReplayRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RpcGetReplayRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RpcGetReplayRequest) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+// Round results for one mode of play.
+type StatsRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rounds won, including by forfeit.
+	Wins int32 `protobuf:"varint,1,opt,name=wins,proto3" json:"wins,omitempty"`
+	// Rounds lost, including by forfeit.
+	Losses int32 `protobuf:"varint,2,opt,name=losses,proto3" json:"losses,omitempty"`
+	// Rounds drawn.
+	Draws int32 `protobuf:"varint,3,opt,name=draws,proto3" json:"draws,omitempty"`
+	// Rounds won because the opponent ran out of time, or left.
+	ForfeitWins int32 `protobuf:"varint,4,opt,name=forfeit_wins,json=forfeitWins,proto3" json:"forfeit_wins,omitempty"`
+	// Rounds lost because the player ran out of time, or left.
+	ForfeitLosses int32 `protobuf:"varint,5,opt,name=forfeit_losses,json=forfeitLosses,proto3" json:"forfeit_losses,omitempty"`
+}
+
+func (x *StatsRecord) Reset() {
+	*x = StatsRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsRecord) ProtoMessage() {}
+
+func (x *StatsRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsRecord.ProtoReflect.Descriptor instead.
+func (*StatsRecord) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StatsRecord) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetForfeitWins() int32 {
+	if x != nil {
+		return x.ForfeitWins
+	}
+	return 0
+}
+
+func (x *StatsRecord) GetForfeitLosses() int32 {
+	if x != nil {
+		return x.ForfeitLosses
+	}
+	return 0
+}
+
+// A player's round results, split by speed and opponent.
+type Stats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fast matches against other players.
+	FastHuman *StatsRecord `protobuf:"bytes,1,opt,name=fast_human,json=fastHuman,proto3" json:"fast_human,omitempty"`
+	// Fast matches against the AI.
+	FastAi *StatsRecord `protobuf:"bytes,2,opt,name=fast_ai,json=fastAi,proto3" json:"fast_ai,omitempty"`
+	// Normal speed matches against other players.
+	NormalHuman *StatsRecord `protobuf:"bytes,3,opt,name=normal_human,json=normalHuman,proto3" json:"normal_human,omitempty"`
+	// Normal speed matches against the AI.
+	NormalAi *StatsRecord `protobuf:"bytes,4,opt,name=normal_ai,json=normalAi,proto3" json:"normal_ai,omitempty"`
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Stats) GetFastHuman() *StatsRecord {
+	if x != nil {
+		return x.FastHuman
+	}
+	return nil
+}
+
+func (x *Stats) GetFastAi() *StatsRecord {
+	if x != nil {
+		return x.FastAi
+	}
+	return nil
+}
+
+func (x *Stats) GetNormalHuman() *StatsRecord {
+	if x != nil {
+		return x.NormalHuman
+	}
+	return nil
+}
+
+func (x *Stats) GetNormalAi() *StatsRecord {
+	if x != nil {
+		return x.NormalAi
+	}
+	return nil
+}
+
+// A round in a player's match history.
+type MatchHistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match the round was played in.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The number of the round in the match, starting from 1.
+	Round int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	// The game that was played.
+	Game string `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	// The user ID of the opponent.
+	OpponentId string `protobuf:"bytes,4,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"`
+	// True if the opponent was the AI.
+	Ai bool `protobuf:"varint,5,opt,name=ai,proto3" json:"ai,omitempty"`
+	// True if the match was played at fast speed.
+	Fast bool `protobuf:"varint,6,opt,name=fast,proto3" json:"fast,omitempty"`
+	// The mark the player played.
+	Mark Mark `protobuf:"varint,7,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
+	// The outcome of the round for the player.
+	Result RoundResult `protobuf:"varint,8,opt,name=result,proto3,enum=api.RoundResult" json:"result,omitempty"`
+	// True if the round was decided by a player running out of time, or leaving.
+	Forfeit bool `protobuf:"varint,9,opt,name=forfeit,proto3" json:"forfeit,omitempty"`
+	// The time the round ended, in UNIX time.
+	EndTime int64 `protobuf:"varint,10,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// How the round ended.
+	Reason DoneReason `protobuf:"varint,11,opt,name=reason,proto3,enum=api.DoneReason" json:"reason,omitempty"`
+}
+
+func (x *MatchHistoryEntry) Reset() {
+	*x = MatchHistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryEntry) ProtoMessage() {}
+
+func (x *MatchHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryEntry.ProtoReflect.Descriptor instead.
+func (*MatchHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MatchHistoryEntry) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchHistoryEntry) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *MatchHistoryEntry) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *MatchHistoryEntry) GetOpponentId() string {
+	if x != nil {
+		return x.OpponentId
+	}
+	return ""
+}
+
+func (x *MatchHistoryEntry) GetAi() bool {
+	if x != nil {
+		return x.Ai
+	}
+	return false
+}
+
+func (x *MatchHistoryEntry) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *MatchHistoryEntry) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *MatchHistoryEntry) GetResult() RoundResult {
+	if x != nil {
+		return x.Result
+	}
+	return RoundResult_ROUND_RESULT_UNSPECIFIED
+}
+
+func (x *MatchHistoryEntry) GetForfeit() bool {
+	if x != nil {
+		return x.Forfeit
+	}
+	return false
+}
+
+func (x *MatchHistoryEntry) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *MatchHistoryEntry) GetReason() DoneReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DoneReason_DONE_REASON_UNSPECIFIED
+}
+
+// Payload for an RPC request to fetch a player's stats.
+type RpcGetStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user to fetch stats for. Defaults to the calling user.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *RpcGetStatsRequest) Reset() {
+	*x = RpcGetStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetStatsRequest) ProtoMessage() {}
+
+func (x *RpcGetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetStatsRequest.ProtoReflect.Descriptor instead.
+func (*RpcGetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RpcGetStatsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Payload for an RPC request to list the calling user's match history, most recent first.
+type RpcListMatchHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of rounds to return, between 1 and 100. Defaults to 20.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor from a previous response, to fetch the next page.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListMatchHistoryRequest) Reset() {
+	*x = RpcListMatchHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListMatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListMatchHistoryRequest) ProtoMessage() {}
+
+func (x *RpcListMatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListMatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*RpcListMatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RpcListMatchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcListMatchHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC response containing a page of the user's match history.
+type RpcListMatchHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rounds played, most recent first.
+	Entries []*MatchHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Cursor to fetch the next page, empty if there are no more rounds.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListMatchHistoryResponse) Reset() {
+	*x = RpcListMatchHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListMatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListMatchHistoryResponse) ProtoMessage() {}
+
+func (x *RpcListMatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListMatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*RpcListMatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RpcListMatchHistoryResponse) GetEntries() []*MatchHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *RpcListMatchHistoryResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC request to create a private match, only joinable with its code.
+type RpcCreatePrivateMatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User can choose a fast or normal speed match.
+	Fast bool `protobuf:"varint,1,opt,name=fast,proto3" json:"fast,omitempty"`
+	// User can choose which game to play: "tic-tac-toe", "connect-four" or "reversi". Defaults to "tic-tac-toe".
+	Game string `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	// User can choose the number of columns on the board. Defaults to the game's usual board.
+	Width int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	// User can choose the number of rows on the board. Defaults to the game's usual board.
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// User can choose the number of marks in a row needed to win. Defaults to the game's usual rules.
+	WinLength int32 `protobuf:"varint,5,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// User can choose to play a series, won by whoever wins the majority of this many rounds. Must be odd.
+	BestOf int32 `protobuf:"varint,6,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// User can choose a chess clock, where each player has this many seconds in total for their moves in a round.
+	TimeBankSec int32 `protobuf:"varint,7,opt,name=time_bank_sec,json=timeBankSec,proto3" json:"time_bank_sec,omitempty"`
+	// User can choose how many seconds are added to a player's time bank after each of their moves.
+	IncrementSec int32 `protobuf:"varint,8,opt,name=increment_sec,json=incrementSec,proto3" json:"increment_sec,omitempty"`
+}
+
+func (x *RpcCreatePrivateMatchRequest) Reset() {
+	*x = RpcCreatePrivateMatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcCreatePrivateMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCreatePrivateMatchRequest) ProtoMessage() {}
+
+func (x *RpcCreatePrivateMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCreatePrivateMatchRequest.ProtoReflect.Descriptor instead.
+func (*RpcCreatePrivateMatchRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetTimeBankSec() int32 {
+	if x != nil {
+		return x.TimeBankSec
+	}
+	return 0
+}
+
+func (x *RpcCreatePrivateMatchRequest) GetIncrementSec() int32 {
+	if x != nil {
+		return x.IncrementSec
+	}
+	return 0
+}
+
+// Payload for an RPC response containing a newly created private match.
+type RpcCreatePrivateMatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match to join.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The code to share with a friend so they can join, also passed as "code" in the join metadata.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *RpcCreatePrivateMatchResponse) Reset() {
+	*x = RpcCreatePrivateMatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcCreatePrivateMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCreatePrivateMatchResponse) ProtoMessage() {}
+
+func (x *RpcCreatePrivateMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCreatePrivateMatchResponse.ProtoReflect.Descriptor instead.
+func (*RpcCreatePrivateMatchResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RpcCreatePrivateMatchResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RpcCreatePrivateMatchResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Payload for an RPC request to find a private match by its code.
+type RpcJoinByCodeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The code shared by the user that created the match.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *RpcJoinByCodeRequest) Reset() {
+	*x = RpcJoinByCodeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcJoinByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcJoinByCodeRequest) ProtoMessage() {}
+
+func (x *RpcJoinByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcJoinByCodeRequest.ProtoReflect.Descriptor instead.
+func (*RpcJoinByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RpcJoinByCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// Payload for an RPC response containing the private match a code belongs to.
+type RpcJoinByCodeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The match to join, passing the code as "code" in the join metadata.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+}
+
+func (x *RpcJoinByCodeResponse) Reset() {
+	*x = RpcJoinByCodeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcJoinByCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcJoinByCodeResponse) ProtoMessage() {}
+
+func (x *RpcJoinByCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcJoinByCodeResponse.ProtoReflect.Descriptor instead.
+func (*RpcJoinByCodeResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RpcJoinByCodeResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+// Payload for an RPC request to challenge a friend to a private match.
+type RpcChallengeFriendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The friend to challenge.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User can choose a fast or normal speed match.
+	Fast bool `protobuf:"varint,2,opt,name=fast,proto3" json:"fast,omitempty"`
+	// User can choose which game to play: "tic-tac-toe", "connect-four" or "reversi". Defaults to "tic-tac-toe".
+	Game string `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	// User can choose the number of columns on the board. Defaults to the game's usual board.
+	Width int32 `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	// User can choose the number of rows on the board. Defaults to the game's usual board.
+	Height int32 `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// User can choose the number of marks in a row needed to win. Defaults to the game's usual rules.
+	WinLength int32 `protobuf:"varint,6,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// User can choose to play a series, won by whoever wins the majority of this many rounds. Must be odd.
+	BestOf int32 `protobuf:"varint,7,opt,name=best_of,json=bestOf,proto3" json:"best_of,omitempty"`
+	// User can choose a chess clock, where each player has this many seconds in total for their moves in a round.
+	TimeBankSec int32 `protobuf:"varint,8,opt,name=time_bank_sec,json=timeBankSec,proto3" json:"time_bank_sec,omitempty"`
+	// User can choose how many seconds are added to a player's time bank after each of their moves.
+	IncrementSec int32 `protobuf:"varint,9,opt,name=increment_sec,json=incrementSec,proto3" json:"increment_sec,omitempty"`
+}
+
+func (x *RpcChallengeFriendRequest) Reset() {
+	*x = RpcChallengeFriendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcChallengeFriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcChallengeFriendRequest) ProtoMessage() {}
+
+func (x *RpcChallengeFriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcChallengeFriendRequest.ProtoReflect.Descriptor instead.
+func (*RpcChallengeFriendRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RpcChallengeFriendRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RpcChallengeFriendRequest) GetFast() bool {
+	if x != nil {
+		return x.Fast
+	}
+	return false
+}
+
+func (x *RpcChallengeFriendRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *RpcChallengeFriendRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetBestOf() int32 {
+	if x != nil {
+		return x.BestOf
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetTimeBankSec() int32 {
+	if x != nil {
+		return x.TimeBankSec
+	}
+	return 0
+}
+
+func (x *RpcChallengeFriendRequest) GetIncrementSec() int32 {
+	if x != nil {
+		return x.IncrementSec
+	}
+	return 0
+}
+
+// Payload for an RPC response to a challenge being sent.
+type RpcChallengeFriendResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The time the challenge expires if the friend hasn't answered it, in UNIX time.
+	ExpiresAt int64 `protobuf:"varint,1,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *RpcChallengeFriendResponse) Reset() {
+	*x = RpcChallengeFriendResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcChallengeFriendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcChallengeFriendResponse) ProtoMessage() {}
+
+func (x *RpcChallengeFriendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcChallengeFriendResponse.ProtoReflect.Descriptor instead.
+func (*RpcChallengeFriendResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RpcChallengeFriendResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Payload for an RPC request to accept or decline a challenge.
+type RpcAnswerChallengeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user that sent the challenge.
+	ChallengerId string `protobuf:"bytes,1,opt,name=challenger_id,json=challengerId,proto3" json:"challenger_id,omitempty"`
+}
+
+func (x *RpcAnswerChallengeRequest) Reset() {
+	*x = RpcAnswerChallengeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcAnswerChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcAnswerChallengeRequest) ProtoMessage() {}
+
+func (x *RpcAnswerChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcAnswerChallengeRequest.ProtoReflect.Descriptor instead.
+func (*RpcAnswerChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RpcAnswerChallengeRequest) GetChallengerId() string {
+	if x != nil {
+		return x.ChallengerId
+	}
+	return ""
+}
+
+// Payload for an RPC response to a challenge being accepted.
+type RpcAcceptChallengeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The private match created for the challenge, with spaces reserved for both users.
+	MatchId string `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The code to pass as "code" in the join metadata, also sent to the challenger.
+	Code string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *RpcAcceptChallengeResponse) Reset() {
+	*x = RpcAcceptChallengeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcAcceptChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcAcceptChallengeResponse) ProtoMessage() {}
+
+func (x *RpcAcceptChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcAcceptChallengeResponse.ProtoReflect.Descriptor instead.
+func (*RpcAcceptChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RpcAcceptChallengeResponse) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *RpcAcceptChallengeResponse) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// A turn-based game played outside of a realtime match, with its state kept in storage.
+type AsyncGame struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifies the game.
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// The game being played.
+	Game string `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	// The number of columns on the board.
+	Width int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	// The number of rows on the board.
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// The number of marks in a row needed to win.
+	WinLength int32 `protobuf:"varint,5,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// The current state of the board.
+	Board []Mark `protobuf:"varint,6,rep,packed,name=board,proto3,enum=api.Mark" json:"board,omitempty"`
+	// The assignments of the marks to players.
+	Marks map[string]Mark `protobuf:"bytes,7,rep,name=marks,proto3" json:"marks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=api.Mark"`
+	// Whose turn it is to play.
+	Mark Mark `protobuf:"varint,8,opt,name=mark,proto3,enum=api.Mark" json:"mark,omitempty"`
+	// The deadline time by which the player must submit their move, or forfeit, in UNIX time.
+	Deadline int64 `protobuf:"varint,9,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// How long each player has to make their move, in seconds.
+	TurnSec int64 `protobuf:"varint,10,opt,name=turn_sec,json=turnSec,proto3" json:"turn_sec,omitempty"`
+	// True once the game is over.
+	Done bool `protobuf:"varint,11,opt,name=done,proto3" json:"done,omitempty"`
+	// The winner of the game, if any. Unspecified if it's a draw or still being played.
+	Winner Mark `protobuf:"varint,12,opt,name=winner,proto3,enum=api.Mark" json:"winner,omitempty"`
+	// Winner board positions, if any. May be empty if it's a draw or the winner is by forfeit.
+	WinnerPositions []int32 `protobuf:"varint,13,rep,packed,name=winner_positions,json=winnerPositions,proto3" json:"winner_positions,omitempty"`
+	// True if the game was decided by a player running out of time.
+	Forfeit bool `protobuf:"varint,14,opt,name=forfeit,proto3" json:"forfeit,omitempty"`
+	// The time the game was created, in UNIX time.
+	CreateTime int64 `protobuf:"varint,15,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// The time of the last move, in UNIX time.
+	UpdateTime int64 `protobuf:"varint,16,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *AsyncGame) Reset() {
+	*x = AsyncGame{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AsyncGame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AsyncGame) ProtoMessage() {}
+
+func (x *AsyncGame) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AsyncGame.ProtoReflect.Descriptor instead.
+func (*AsyncGame) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AsyncGame) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *AsyncGame) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *AsyncGame) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetBoard() []Mark {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *AsyncGame) GetMarks() map[string]Mark {
+	if x != nil {
+		return x.Marks
+	}
+	return nil
+}
+
+func (x *AsyncGame) GetMark() Mark {
+	if x != nil {
+		return x.Mark
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *AsyncGame) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetTurnSec() int64 {
+	if x != nil {
+		return x.TurnSec
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *AsyncGame) GetWinner() Mark {
+	if x != nil {
+		return x.Winner
+	}
+	return Mark_MARK_UNSPECIFIED
+}
+
+func (x *AsyncGame) GetWinnerPositions() []int32 {
+	if x != nil {
+		return x.WinnerPositions
+	}
+	return nil
+}
+
+func (x *AsyncGame) GetForfeit() bool {
+	if x != nil {
+		return x.Forfeit
+	}
+	return false
+}
+
+func (x *AsyncGame) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *AsyncGame) GetUpdateTime() int64 {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return 0
+}
+
+// Payload for an RPC request to start an asynchronous game with a friend.
+type RpcCreateAsyncGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The friend to play against.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// User can choose which game to play: "tic-tac-toe", "connect-four" or "reversi". Defaults to "tic-tac-toe".
+	Game string `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	// User can choose the number of columns on the board. Defaults to the game's usual board.
+	Width int32 `protobuf:"varint,3,opt,name=width,proto3" json:"width,omitempty"`
+	// User can choose the number of rows on the board. Defaults to the game's usual board.
+	Height int32 `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	// User can choose the number of marks in a row needed to win. Defaults to the game's usual rules.
+	WinLength int32 `protobuf:"varint,5,opt,name=win_length,json=winLength,proto3" json:"win_length,omitempty"`
+	// User can choose how many hours each player has to make their move, between 1 and 168. Defaults to 24.
+	TurnHours int32 `protobuf:"varint,6,opt,name=turn_hours,json=turnHours,proto3" json:"turn_hours,omitempty"`
+}
+
+func (x *RpcCreateAsyncGameRequest) Reset() {
+	*x = RpcCreateAsyncGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcCreateAsyncGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcCreateAsyncGameRequest) ProtoMessage() {}
+
+func (x *RpcCreateAsyncGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcCreateAsyncGameRequest.ProtoReflect.Descriptor instead.
+func (*RpcCreateAsyncGameRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RpcCreateAsyncGameRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RpcCreateAsyncGameRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *RpcCreateAsyncGameRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *RpcCreateAsyncGameRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RpcCreateAsyncGameRequest) GetWinLength() int32 {
+	if x != nil {
+		return x.WinLength
+	}
+	return 0
+}
+
+func (x *RpcCreateAsyncGameRequest) GetTurnHours() int32 {
+	if x != nil {
+		return x.TurnHours
+	}
+	return 0
+}
+
+// Payload for an RPC request to fetch an asynchronous game.
+type RpcGetAsyncGameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The game to fetch.
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+}
+
+func (x *RpcGetAsyncGameRequest) Reset() {
+	*x = RpcGetAsyncGameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetAsyncGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetAsyncGameRequest) ProtoMessage() {}
+
+func (x *RpcGetAsyncGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetAsyncGameRequest.ProtoReflect.Descriptor instead.
+func (*RpcGetAsyncGameRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RpcGetAsyncGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+// Payload for an RPC request to make a move in an asynchronous game.
+type RpcAsyncMoveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The game to play in.
+	GameId string `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// The position the player wants to place their mark in, numbered row by row from the top left corner.
+	Position int32 `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"`
+}
+
+func (x *RpcAsyncMoveRequest) Reset() {
+	*x = RpcAsyncMoveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcAsyncMoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcAsyncMoveRequest) ProtoMessage() {}
+
+func (x *RpcAsyncMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcAsyncMoveRequest.ProtoReflect.Descriptor instead.
+func (*RpcAsyncMoveRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RpcAsyncMoveRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *RpcAsyncMoveRequest) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// Payload for an RPC request to list the calling user's asynchronous games, most recent first.
+type RpcListAsyncGamesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of games to return, between 1 and 100. Defaults to 20.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Cursor from a previous response, to fetch the next page.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListAsyncGamesRequest) Reset() {
+	*x = RpcListAsyncGamesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListAsyncGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListAsyncGamesRequest) ProtoMessage() {}
+
+func (x *RpcListAsyncGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListAsyncGamesRequest.ProtoReflect.Descriptor instead.
+func (*RpcListAsyncGamesRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RpcListAsyncGamesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RpcListAsyncGamesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Payload for an RPC response containing a page of the user's asynchronous games.
+type RpcListAsyncGamesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Games the user is playing or has played, most recent first.
+	Games []*AsyncGame `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	// Cursor to fetch the next page, empty if there are no more games.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *RpcListAsyncGamesResponse) Reset() {
+	*x = RpcListAsyncGamesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListAsyncGamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListAsyncGamesResponse) ProtoMessage() {}
+
+func (x *RpcListAsyncGamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListAsyncGamesResponse.ProtoReflect.Descriptor instead.
+func (*RpcListAsyncGamesResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RpcListAsyncGamesResponse) GetGames() []*AsyncGame {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+func (x *RpcListAsyncGamesResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// A reward paid out at the end of a ranked season to players finishing at or above a rank.
+type SeasonRewardTier struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The tier's name, also the badge players in it receive.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The lowest rank that earns the tier.
+	MaxRank int64 `protobuf:"varint,2,opt,name=max_rank,json=maxRank,proto3" json:"max_rank,omitempty"`
+	// Coins added to the player's wallet.
+	Coins int64 `protobuf:"varint,3,opt,name=coins,proto3" json:"coins,omitempty"`
+}
+
+func (x *SeasonRewardTier) Reset() {
+	*x = SeasonRewardTier{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeasonRewardTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonRewardTier) ProtoMessage() {}
+
+func (x *SeasonRewardTier) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonRewardTier.ProtoReflect.Descriptor instead.
+func (*SeasonRewardTier) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SeasonRewardTier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SeasonRewardTier) GetMaxRank() int64 {
+	if x != nil {
+		return x.MaxRank
+	}
+	return 0
+}
+
+func (x *SeasonRewardTier) GetCoins() int64 {
+	if x != nil {
+		return x.Coins
+	}
+	return 0
+}
+
+// The ranked season currently in progress.
+type Season struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The season's leaderboard ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Seconds since the Unix epoch when the season started, or 0 if this is the first season.
+	StartTime int64 `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// Seconds since the Unix epoch when the season ends, and rewards are paid out.
+	EndTime int64 `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Rewards for the best players, from the highest tier down.
+	RewardTiers []*SeasonRewardTier `protobuf:"bytes,4,rep,name=reward_tiers,json=rewardTiers,proto3" json:"reward_tiers,omitempty"`
+}
+
+func (x *Season) Reset() {
+	*x = Season{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Season) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Season) ProtoMessage() {}
+
+func (x *Season) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Season.ProtoReflect.Descriptor instead.
+func (*Season) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Season) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Season) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *Season) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *Season) GetRewardTiers() []*SeasonRewardTier {
+	if x != nil {
+		return x.RewardTiers
+	}
+	return nil
+}
+
+// A player's standing in the current ranked season.
+type SeasonRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The player's user ID.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The player's username.
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// The player's rank, starting from 1.
+	Rank int64 `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Points earned in ranked rounds this season.
+	Points int64 `protobuf:"varint,4,opt,name=points,proto3" json:"points,omitempty"`
+	// Ranked rounds won this season.
+	Wins int64 `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	// The reward tier the player would earn if the season ended now, if any.
+	Tier string `protobuf:"bytes,6,opt,name=tier,proto3" json:"tier,omitempty"`
+}
+
+func (x *SeasonRecord) Reset() {
+	*x = SeasonRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeasonRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeasonRecord) ProtoMessage() {}
+
+func (x *SeasonRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeasonRecord.ProtoReflect.Descriptor instead.
+func (*SeasonRecord) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SeasonRecord) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SeasonRecord) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SeasonRecord) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetWins() int64 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *SeasonRecord) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+// Payload for an RPC response containing the calling user's standing in the current ranked season.
+type RpcGetSeasonStandingResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user's standing. Empty if they haven't played a ranked round this season.
+	Record *SeasonRecord `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *RpcGetSeasonStandingResponse) Reset() {
+	*x = RpcGetSeasonStandingResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetSeasonStandingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetSeasonStandingResponse) ProtoMessage() {}
+
+func (x *RpcGetSeasonStandingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetSeasonStandingResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetSeasonStandingResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RpcGetSeasonStandingResponse) GetRecord() *SeasonRecord {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+// Payload for an RPC request to list the players ranked around the calling user this season.
+type RpcListSeasonAroundRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The maximum number of players to return, between 1 and 100. Defaults to 20.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *RpcListSeasonAroundRequest) Reset() {
+	*x = RpcListSeasonAroundRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListSeasonAroundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListSeasonAroundRequest) ProtoMessage() {}
+
+func (x *RpcListSeasonAroundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListSeasonAroundRequest.ProtoReflect.Descriptor instead.
+func (*RpcListSeasonAroundRequest) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RpcListSeasonAroundRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Payload for an RPC response containing the players ranked around the calling user.
+type RpcListSeasonAroundResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Players in rank order, including the user.
+	Records []*SeasonRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *RpcListSeasonAroundResponse) Reset() {
+	*x = RpcListSeasonAroundResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListSeasonAroundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListSeasonAroundResponse) ProtoMessage() {}
+
+func (x *RpcListSeasonAroundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListSeasonAroundResponse.ProtoReflect.Descriptor instead.
+func (*RpcListSeasonAroundResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RpcListSeasonAroundResponse) GetRecords() []*SeasonRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+// Two players drawn against each other in a tournament bracket.
+type TournamentPairing struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The higher seeded player, or the winner of the upper pairing in the previous round.
+	PlayerA string `protobuf:"bytes,1,opt,name=player_a,json=playerA,proto3" json:"player_a,omitempty"`
+	// The other player. Empty if player A has a bye.
+	PlayerB string `protobuf:"bytes,2,opt,name=player_b,json=playerB,proto3" json:"player_b,omitempty"`
+	// The match the pairing is played in, once it has been created.
+	MatchId string `protobuf:"bytes,3,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	// The user ID that goes through to the next round, once decided.
+	Winner string `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`
+}
+
+func (x *TournamentPairing) Reset() {
+	*x = TournamentPairing{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentPairing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPairing) ProtoMessage() {}
+
+func (x *TournamentPairing) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPairing.ProtoReflect.Descriptor instead.
+func (*TournamentPairing) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TournamentPairing) GetPlayerA() string {
+	if x != nil {
+		return x.PlayerA
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetPlayerB() string {
+	if x != nil {
+		return x.PlayerB
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *TournamentPairing) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+// One round of a tournament bracket.
+type TournamentRound struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Pairings in bracket order. Winners of neighbouring pairings meet in the next round.
+	Pairings []*TournamentPairing `protobuf:"bytes,1,rep,name=pairings,proto3" json:"pairings,omitempty"`
+}
+
+func (x *TournamentRound) Reset() {
+	*x = TournamentRound{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentRound) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRound) ProtoMessage() {}
+
+func (x *TournamentRound) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRound.ProtoReflect.Descriptor instead.
+func (*TournamentRound) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *TournamentRound) GetPairings() []*TournamentPairing {
+	if x != nil {
+		return x.Pairings
+	}
+	return nil
+}
+
+// A single elimination bracket for one run of a tournament.
+type TournamentBracket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The tournament ID.
+	TournamentId string `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	// Seconds since the Unix epoch when this run of the tournament ends.
+	EndTime int64 `protobuf:"varint,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Rounds played so far, starting with the first.
+	Rounds []*TournamentRound `protobuf:"bytes,3,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	// The user ID that won the tournament, once it's over.
+	Winner string `protobuf:"bytes,4,opt,name=winner,proto3" json:"winner,omitempty"`
+}
+
+func (x *TournamentBracket) Reset() {
+	*x = TournamentBracket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TournamentBracket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentBracket) ProtoMessage() {}
+
+func (x *TournamentBracket) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentBracket.ProtoReflect.Descriptor instead.
+func (*TournamentBracket) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TournamentBracket) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *TournamentBracket) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *TournamentBracket) GetRounds() []*TournamentRound {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *TournamentBracket) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+// Payload for an RPC response containing the bracket of the tournament currently running.
+type RpcGetTournamentBracketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The bracket. Empty while players can still join.
+	Bracket *TournamentBracket `protobuf:"bytes,1,opt,name=bracket,proto3" json:"bracket,omitempty"`
+	// Seconds since the Unix epoch when joining closes and the bracket is drawn.
+	SignupEndTime int64 `protobuf:"varint,2,opt,name=signup_end_time,json=signupEndTime,proto3" json:"signup_end_time,omitempty"`
+}
+
+func (x *RpcGetTournamentBracketResponse) Reset() {
+	*x = RpcGetTournamentBracketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetTournamentBracketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetTournamentBracketResponse) ProtoMessage() {}
+
+func (x *RpcGetTournamentBracketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetTournamentBracketResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetTournamentBracketResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RpcGetTournamentBracketResponse) GetBracket() *TournamentBracket {
+	if x != nil {
+		return x.Bracket
+	}
+	return nil
+}
+
+func (x *RpcGetTournamentBracketResponse) GetSignupEndTime() int64 {
+	if x != nil {
+		return x.SignupEndTime
+	}
+	return 0
+}
+
+// An achievement and the user's progress towards it.
+type Achievement struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The achievement ID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The achievement's display name.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// What the user has to do to unlock it.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// The progress needed to unlock it.
+	Target int64 `protobuf:"varint,4,opt,name=target,proto3" json:"target,omitempty"`
+	// The user's progress so far, up to the target.
+	Progress int64 `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	// Seconds since the Unix epoch when the user unlocked it, or 0 if they haven't yet.
+	UnlockTime int64 `protobuf:"varint,6,opt,name=unlock_time,json=unlockTime,proto3" json:"unlock_time,omitempty"`
+	// Currencies added to the user's wallet when they unlock it.
+	Reward map[string]int64 `protobuf:"bytes,7,rep,name=reward,proto3" json:"reward,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *Achievement) Reset() {
+	*x = Achievement{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Achievement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Achievement) ProtoMessage() {}
+
+func (x *Achievement) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Achievement.ProtoReflect.Descriptor instead.
+func (*Achievement) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Achievement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Achievement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Achievement) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Achievement) GetTarget() int64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *Achievement) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Achievement) GetUnlockTime() int64 {
+	if x != nil {
+		return x.UnlockTime
+	}
+	return 0
+}
+
+func (x *Achievement) GetReward() map[string]int64 {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+// Payload for an RPC response containing every achievement and the calling user's progress.
+type RpcListAchievementsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Achievements in the order they're defined.
+	Achievements []*Achievement `protobuf:"bytes,1,rep,name=achievements,proto3" json:"achievements,omitempty"`
+}
+
+func (x *RpcListAchievementsResponse) Reset() {
+	*x = RpcListAchievementsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcListAchievementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcListAchievementsResponse) ProtoMessage() {}
+
+func (x *RpcListAchievementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcListAchievementsResponse.ProtoReflect.Descriptor instead.
+func (*RpcListAchievementsResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RpcListAchievementsResponse) GetAchievements() []*Achievement {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+// A day in the daily reward calendar.
+type RewardCalendarDay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The day of the streak this reward is for, starting from 1.
+	Day int32 `protobuf:"varint,1,opt,name=day,proto3" json:"day,omitempty"`
+	// Currencies added to the user's wallet when they claim it.
+	Reward map[string]int64 `protobuf:"bytes,2,rep,name=reward,proto3" json:"reward,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RewardCalendarDay) Reset() {
+	*x = RewardCalendarDay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RewardCalendarDay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardCalendarDay) ProtoMessage() {}
+
+func (x *RewardCalendarDay) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardCalendarDay.ProtoReflect.Descriptor instead.
+func (*RewardCalendarDay) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RewardCalendarDay) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *RewardCalendarDay) GetReward() map[string]int64 {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+// Payload for an RPC response containing the daily reward calendar and the calling user's place in it.
+type RpcGetRewardCalendarResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Every day of the calendar. Once the last day is claimed, the calendar starts again from the first.
+	Days []*RewardCalendarDay `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	// Days in a row the user has claimed their reward. 0 if the streak has been broken.
+	Streak int32 `protobuf:"varint,2,opt,name=streak,proto3" json:"streak,omitempty"`
+	// The calendar day the user's next claim is for, starting from 1.
+	NextDay int32 `protobuf:"varint,3,opt,name=next_day,json=nextDay,proto3" json:"next_day,omitempty"`
+	// True if the user has already claimed today's reward.
+	ClaimedToday bool `protobuf:"varint,4,opt,name=claimed_today,json=claimedToday,proto3" json:"claimed_today,omitempty"`
+	// Seconds since the Unix epoch when the user can next claim a reward.
+	NextClaimTime int64 `protobuf:"varint,5,opt,name=next_claim_time,json=nextClaimTime,proto3" json:"next_claim_time,omitempty"`
+	// True if the user missed a day recently enough to pay to keep their streak.
+	Repairable bool `protobuf:"varint,6,opt,name=repairable,proto3" json:"repairable,omitempty"`
+	// Currencies taken from the user's wallet to keep their streak.
+	RepairCost map[string]int64 `protobuf:"bytes,7,rep,name=repair_cost,json=repairCost,proto3" json:"repair_cost,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *RpcGetRewardCalendarResponse) Reset() {
+	*x = RpcGetRewardCalendarResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_xoxoapi_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RpcGetRewardCalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RpcGetRewardCalendarResponse) ProtoMessage() {}
+
+func (x *RpcGetRewardCalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_xoxoapi_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RpcGetRewardCalendarResponse.ProtoReflect.Descriptor instead.
+func (*RpcGetRewardCalendarResponse) Descriptor() ([]byte, []int) {
+	return file_xoxoapi_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RpcGetRewardCalendarResponse) GetDays() []*RewardCalendarDay {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *RpcGetRewardCalendarResponse) GetStreak() int32 {
+	if x != nil {
+		return x.Streak
+	}
+	return 0
+}
+
+func (x *RpcGetRewardCalendarResponse) GetNextDay() int32 {
+	if x != nil {
+		return x.NextDay
+	}
+	return 0
+}
+
+func (x *RpcGetRewardCalendarResponse) GetClaimedToday() bool {
+	if x != nil {
+		return x.ClaimedToday
+	}
+	return false
+}
+
+func (x *RpcGetRewardCalendarResponse) GetNextClaimTime() int64 {
+	if x != nil {
+		return x.NextClaimTime
+	}
+	return 0
+}
+
+func (x *RpcGetRewardCalendarResponse) GetRepairable() bool {
+	if x != nil {
+		return x.Repairable
+	}
+	return false
+}
+
+func (x *RpcGetRewardCalendarResponse) GetRepairCost() map[string]int64 {
+	if x != nil {
+		return x.RepairCost
+	}
+	return nil
+}
+
+var File_xoxoapi_proto protoreflect.FileDescriptor
+
+var file_xoxoapi_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x78, 0x6f, 0x78, 0x6f, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x03, 0x61, 0x70, 0x69, 0x22, 0xc9, 0x04, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x1f,
+	0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12,
+	0x2b, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x3e, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x38, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b,
+	0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x1a, 0x43, 0x0a,
+	0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x9d, 0x03, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62,
+	0x6f, 0x61, 0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72,
+	0x6b, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x1a, 0x43, 0x0a,
+	0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x54, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xdb, 0x02, 0x0a, 0x04, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d,
+	0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x06, 0x77, 0x69,
+	0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a,
+	0x10, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x3d, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6e,
+	0x65, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6e, 0x65,
+	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x3e,
+	0x0a, 0x10, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc2,
+	0x01, 0x0a, 0x0a, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x43, 0x0a,
+	0x0c, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x44, 0x6f, 0x6e, 0x65, 0x2e, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x65,
+	0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x73,
+	0x74, 0x4f, 0x66, 0x1a, 0x3e, 0x0a, 0x10, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x3f, 0x0a, 0x0c, 0x44, 0x72, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x22, 0x22, 0x0a, 0x07, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x05, 0x50, 0x61, 0x75, 0x73,
+	0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x22, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x92, 0x01, 0x0a, 0x0a, 0x52,
+	0x65, 0x70, 0x6c, 0x61, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72,
+	0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x63,
+	0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0xc0, 0x04, 0x0a, 0x06, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x67,
+	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x05,
+	0x6d, 0x61, 0x72, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x6d, 0x6f,
+	0x76, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x63, 0x6b,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x63, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e,
+	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52,
+	0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x21, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72,
+	0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x69, 0x6e,
+	0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6e, 0x65, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x43, 0x0a,
+	0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xad, 0x02, 0x0a, 0x13, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x61, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x61, 0x69, 0x12, 0x2f,
+	0x0a, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75,
+	0x6c, 0x74, 0x79, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x12, 0x22, 0x0a, 0x0d, 0x74, 0x69, 0x6d,
+	0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x53, 0x65, 0x63, 0x12, 0x23, 0x0a,
+	0x0d, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53,
+	0x65, 0x63, 0x22, 0x33, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x46, 0x69, 0x6e, 0x64, 0x4d, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x73, 0x22, 0x46, 0x0a, 0x13, 0x52, 0x70, 0x63, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22,
+	0x99, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x77,
+	0x69, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x72, 0x61, 0x77, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x72, 0x61, 0x77,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x5f, 0x77, 0x69, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74,
+	0x57, 0x69, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x5f,
+	0x6c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x6f,
+	0x72, 0x66, 0x65, 0x69, 0x74, 0x4c, 0x6f, 0x73, 0x73, 0x65, 0x73, 0x22, 0xc7, 0x01, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x0a, 0x66, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x75,
+	0x6d, 0x61, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x09, 0x66, 0x61, 0x73,
+	0x74, 0x48, 0x75, 0x6d, 0x61, 0x6e, 0x12, 0x29, 0x0a, 0x07, 0x66, 0x61, 0x73, 0x74, 0x5f, 0x61,
+	0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x66, 0x61, 0x73, 0x74, 0x41,
+	0x69, 0x12, 0x33, 0x0a, 0x0c, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x5f, 0x68, 0x75, 0x6d, 0x61,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0b, 0x6e, 0x6f, 0x72, 0x6d, 0x61,
+	0x6c, 0x48, 0x75, 0x6d, 0x61, 0x6e, 0x12, 0x2d, 0x0a, 0x09, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c,
+	0x5f, 0x61, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x08, 0x6e, 0x6f, 0x72,
+	0x6d, 0x61, 0x6c, 0x41, 0x69, 0x22, 0xc4, 0x02, 0x0a, 0x11, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x61, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x61,
+	0x69, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x44, 0x6f, 0x6e, 0x65, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x12,
+	0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4a, 0x0a, 0x1a, 0x52,
+	0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x67, 0x0a, 0x1b, 0x52, 0x70, 0x63, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0xf5, 0x01, 0x0a, 0x1c, 0x52, 0x70, 0x63, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x66, 0x61, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e,
+	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f,
+	0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x12,
+	0x22, 0x0a, 0x0d, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x73, 0x65, 0x63,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b,
+	0x53, 0x65, 0x63, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x5f, 0x73, 0x65, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x72,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x22, 0x4e, 0x0a, 0x1d, 0x52, 0x70, 0x63, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x2a, 0x0a, 0x14, 0x52, 0x70, 0x63, 0x4a,
+	0x6f, 0x69, 0x6e, 0x42, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x22, 0x32, 0x0a, 0x15, 0x52, 0x70, 0x63, 0x4a, 0x6f, 0x69, 0x6e, 0x42,
+	0x79, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x22, 0x8b, 0x02, 0x0a, 0x19, 0x52, 0x70, 0x63,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x66, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66,
+	0x61, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65,
+	0x6e, 0x67, 0x74, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x66, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x65, 0x73, 0x74, 0x4f, 0x66, 0x12, 0x22, 0x0a,
+	0x0d, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x73, 0x65, 0x63, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x53, 0x65,
+	0x63, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x73,
+	0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x22, 0x3b, 0x0a, 0x1a, 0x52, 0x70, 0x63, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x46, 0x72, 0x69, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x22, 0x40, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x72, 0x49, 0x64, 0x22, 0x4b, 0x0a, 0x1a, 0x52, 0x70, 0x63, 0x41, 0x63, 0x63, 0x65,
+	0x70, 0x74, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x22, 0xb0, 0x04, 0x0a, 0x09, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x67, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x77,
+	0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x05, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x12, 0x2f, 0x0a, 0x05, 0x6d,
+	0x61, 0x72, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x6d, 0x61, 0x72, 0x6b, 0x73, 0x12, 0x1d, 0x0a, 0x04,
+	0x6d, 0x61, 0x72, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x04, 0x6d, 0x61, 0x72, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x75, 0x72, 0x6e, 0x5f,
+	0x73, 0x65, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x75, 0x72, 0x6e, 0x53,
+	0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x21, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72,
+	0x6b, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x69, 0x6e,
+	0x6e, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0d, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0f, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x66, 0x6f, 0x72, 0x66, 0x65, 0x69, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65,
+	0x1a, 0x43, 0x0a, 0x0a, 0x4d, 0x61, 0x72, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x1f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x09, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4d, 0x61, 0x72, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb4, 0x01, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x67, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x74, 0x75, 0x72, 0x6e, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x31, 0x0a, 0x16,
+	0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22,
+	0x4a, 0x0a, 0x13, 0x52, 0x70, 0x63, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x48, 0x0a, 0x18, 0x52,
+	0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x59, 0x0a, 0x19, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74,
+	0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x47, 0x61, 0x6d,
+	0x65, 0x52, 0x05, 0x67, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x22, 0x57, 0x0a, 0x10, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x54, 0x69, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f,
+	0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x52,
+	0x61, 0x6e, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x69, 0x6e, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x06, 0x53, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x38,
+	0x0a, 0x0c, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x74, 0x69, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x54, 0x69, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x54, 0x69, 0x65, 0x72, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x72, 0x61,
+	0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x69,
+	0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x77, 0x69, 0x6e, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x69,
+	0x65, 0x72, 0x22, 0x49, 0x0a, 0x1c, 0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x32, 0x0a,
+	0x1a, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x41, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0x4a, 0x0a, 0x1b, 0x52, 0x70, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x41, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2b, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x7c, 0x0a,
+	0x11, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x61, 0x69, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x41, 0x12, 0x19, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x42, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x45, 0x0a, 0x0f, 0x54,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x32,
+	0x0a, 0x08, 0x70, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x52, 0x08, 0x70, 0x61, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x73, 0x22, 0x99, 0x01, 0x0a, 0x11, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e,
+	0x74, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x75, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x74, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x19, 0x0a,
+	0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54,
+	0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x06,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x22, 0x7b,
+	0x0a, 0x1f, 0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x6e, 0x74, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x30, 0x0a, 0x07, 0x62, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x6f, 0x75, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x6e, 0x74, 0x42, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x75, 0x70, 0x5f, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x69,
+	0x67, 0x6e, 0x75, 0x70, 0x45, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x99, 0x02, 0x0a, 0x0b,
+	0x41, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x41, 0x63, 0x68,
+	0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x1a, 0x39, 0x0a, 0x0b,
+	0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x53, 0x0a, 0x1b, 0x52, 0x70, 0x63, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x61, 0x63, 0x68, 0x69, 0x65, 0x76,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x41, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0c,
+	0x61, 0x63, 0x68, 0x69, 0x65, 0x76, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x9c, 0x01, 0x0a,
+	0x11, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x44,
+	0x61, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x64, 0x61, 0x79, 0x12, 0x3a, 0x0a, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x77, 0x61, 0x72,
+	0x64, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x44, 0x61, 0x79, 0x2e, 0x52, 0x65, 0x77,
+	0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x72, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x1a, 0x39, 0x0a, 0x0b, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xfd, 0x02, 0x0a, 0x1c,
+	0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x43, 0x61, 0x6c, 0x65,
+	0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04,
+	0x64, 0x61, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64, 0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x44,
+	0x61, 0x79, 0x52, 0x04, 0x64, 0x61, 0x79, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6b,
+	0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x44, 0x61, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x64, 0x61, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x54, 0x6f, 0x64, 0x61, 0x79,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x43,
+	0x6c, 0x61, 0x69, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x65,
+	0x70, 0x61, 0x69, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x52, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x61,
+	0x69, 0x72, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x52, 0x70, 0x63, 0x47, 0x65, 0x74, 0x52, 0x65, 0x77, 0x61, 0x72, 0x64,
+	0x43, 0x61, 0x6c, 0x65, 0x6e, 0x64, 0x61, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x2e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0a, 0x72, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x73, 0x74, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x6f, 0x73, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x34, 0x0a, 0x04, 0x4d,
+	0x61, 0x72, 0x6b, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52,
+	0x4b, 0x5f, 0x58, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x41, 0x52, 0x4b, 0x5f, 0x4f, 0x10,
+	0x02, 0x2a, 0x84, 0x03, 0x0a, 0x06, 0x4f, 0x70, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x12,
+	0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53,
+	0x54, 0x41, 0x52, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45,
+	0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4d, 0x4f, 0x56, 0x45, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x4f,
+	0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x05,
+	0x12, 0x18, 0x0a, 0x14, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x50, 0x50, 0x4f, 0x4e,
+	0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x06, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x56, 0x49, 0x54, 0x45, 0x5f, 0x41, 0x49, 0x10, 0x07,
+	0x12, 0x16, 0x0a, 0x12, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x49, 0x45,
+	0x53, 0x5f, 0x44, 0x4f, 0x4e, 0x45, 0x10, 0x08, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50, 0x43, 0x4f,
+	0x44, 0x45, 0x5f, 0x41, 0x49, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x09, 0x12, 0x1a,
+	0x0a, 0x16, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x0a, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x50,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x41, 0x43, 0x43,
+	0x45, 0x50, 0x54, 0x10, 0x0b, 0x12, 0x1a, 0x0a, 0x16, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x52, 0x45, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x44, 0x45, 0x43, 0x4c, 0x49, 0x4e, 0x45, 0x10,
+	0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x49,
+	0x47, 0x4e, 0x10, 0x0d, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x44,
+	0x52, 0x41, 0x57, 0x5f, 0x4f, 0x46, 0x46, 0x45, 0x52, 0x10, 0x0e, 0x12, 0x18, 0x0a, 0x14, 0x4f,
+	0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f,
+	0x4e, 0x53, 0x45, 0x10, 0x0f, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x50, 0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x10, 0x2a, 0x81, 0x01, 0x0a, 0x0a, 0x44, 0x69, 0x66,
+	0x66, 0x69, 0x63, 0x75, 0x6c, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x16, 0x44, 0x49, 0x46, 0x46, 0x49,
+	0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54,
+	0x59, 0x5f, 0x45, 0x41, 0x53, 0x59, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x49, 0x46, 0x46,
+	0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x55, 0x4d, 0x10, 0x02, 0x12,
+	0x13, 0x0a, 0x0f, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c, 0x54, 0x59, 0x5f, 0x48, 0x41,
+	0x52, 0x44, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x44, 0x49, 0x46, 0x46, 0x49, 0x43, 0x55, 0x4c,
+	0x54, 0x59, 0x5f, 0x50, 0x45, 0x52, 0x46, 0x45, 0x43, 0x54, 0x10, 0x04, 0x2a, 0xc0, 0x01, 0x0a,
+	0x0a, 0x44, 0x6f, 0x6e, 0x65, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x44,
+	0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x44, 0x4f, 0x4e, 0x45,
+	0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x57, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x13, 0x0a,
+	0x0f, 0x44, 0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x54, 0x49, 0x45,
+	0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f,
+	0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x44,
+	0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x53, 0x49, 0x47,
+	0x4e, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53,
+	0x4f, 0x4e, 0x5f, 0x41, 0x47, 0x52, 0x45, 0x45, 0x44, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x10, 0x05,
+	0x12, 0x1d, 0x0a, 0x19, 0x44, 0x4f, 0x4e, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f,
+	0x4f, 0x50, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x4c, 0x45, 0x46, 0x54, 0x10, 0x06, 0x2a,
+	0x6f, 0x0a, 0x0b, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1c,
+	0x0a, 0x18, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10,
+	0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x57, 0x49, 0x4e,
+	0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x55,
+	0x4c, 0x54, 0x5f, 0x4c, 0x4f, 0x53, 0x53, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x52, 0x4f, 0x55,
+	0x4e, 0x44, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x44, 0x52, 0x41, 0x57, 0x10, 0x03,
+	0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68,
+	0x65, 0x72, 0x6f, 0x69, 0x63, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x6e, 0x61, 0x6b, 0x61, 0x6d, 0x61,
+	0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x2d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_xoxoapi_proto_rawDescOnce sync.Once
+	file_xoxoapi_proto_rawDescData = file_xoxoapi_proto_rawDesc
+)
+
+func file_xoxoapi_proto_rawDescGZIP() []byte {
+	file_xoxoapi_proto_rawDescOnce.Do(func() {
+		file_xoxoapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_xoxoapi_proto_rawDescData)
+	})
+	return file_xoxoapi_proto_rawDescData
+}
+
+var file_xoxoapi_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_xoxoapi_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
+var file_xoxoapi_proto_goTypes = []interface{}{
+	(Mark)(0),                               // 0: api.Mark
+	(OpCode)(0),                             // 1: api.OpCode
+	(Difficulty)(0),                         // 2: api.Difficulty
+	(DoneReason)(0),                         // 3: api.DoneReason
+	(RoundResult)(0),                        // 4: api.RoundResult
+	(*Start)(nil),                           // 5: api.Start
+	(*Update)(nil),                          // 6: api.Update
+	(*Done)(nil),                            // 7: api.Done
+	(*SeriesDone)(nil),                      // 8: api.SeriesDone
+	(*DrawResponse)(nil),                    // 9: api.DrawResponse
+	(*Rematch)(nil),                         // 10: api.Rematch
+	(*Pause)(nil),                           // 11: api.Pause
+	(*Move)(nil),                            // 12: api.Move
+	(*ReplayMove)(nil),                      // 13: api.ReplayMove
+	(*Replay)(nil),                          // 14: api.Replay
+	(*RpcFindMatchRequest)(nil),             // 15: api.RpcFindMatchRequest
+	(*RpcFindMatchResponse)(nil),            // 16: api.RpcFindMatchResponse
+	(*RpcGetReplayRequest)(nil),             // 17: api.RpcGetReplayRequest
+	(*StatsRecord)(nil),                     // 18: api.StatsRecord
+	(*Stats)(nil),                           // 19: api.Stats
+	(*MatchHistoryEntry)(nil),               // 20: api.MatchHistoryEntry
+	(*RpcGetStatsRequest)(nil),              // 21: api.RpcGetStatsRequest
+	(*RpcListMatchHistoryRequest)(nil),      // 22: api.RpcListMatchHistoryRequest
+	(*RpcListMatchHistoryResponse)(nil),     // 23: api.RpcListMatchHistoryResponse
+	(*RpcCreatePrivateMatchRequest)(nil),    // 24: api.RpcCreatePrivateMatchRequest
+	(*RpcCreatePrivateMatchResponse)(nil),   // 25: api.RpcCreatePrivateMatchResponse
+	(*RpcJoinByCodeRequest)(nil),            // 26: api.RpcJoinByCodeRequest
+	(*RpcJoinByCodeResponse)(nil),           // 27: api.RpcJoinByCodeResponse
+	(*RpcChallengeFriendRequest)(nil),       // 28: api.RpcChallengeFriendRequest
+	(*RpcChallengeFriendResponse)(nil),      // 29: api.RpcChallengeFriendResponse
+	(*RpcAnswerChallengeRequest)(nil),       // 30: api.RpcAnswerChallengeRequest
+	(*RpcAcceptChallengeResponse)(nil),      // 31: api.RpcAcceptChallengeResponse
+	(*AsyncGame)(nil),                       // 32: api.AsyncGame
+	(*RpcCreateAsyncGameRequest)(nil),       // 33: api.RpcCreateAsyncGameRequest
+	(*RpcGetAsyncGameRequest)(nil),          // 34: api.RpcGetAsyncGameRequest
+	(*RpcAsyncMoveRequest)(nil),             // 35: api.RpcAsyncMoveRequest
+	(*RpcListAsyncGamesRequest)(nil),        // 36: api.RpcListAsyncGamesRequest
+	(*RpcListAsyncGamesResponse)(nil),       // 37: api.RpcListAsyncGamesResponse
+	(*SeasonRewardTier)(nil),                // 38: api.SeasonRewardTier
+	(*Season)(nil),                          // 39: api.Season
+	(*SeasonRecord)(nil),                    // 40: api.SeasonRecord
+	(*RpcGetSeasonStandingResponse)(nil),    // 41: api.RpcGetSeasonStandingResponse
+	(*RpcListSeasonAroundRequest)(nil),      // 42: api.RpcListSeasonAroundRequest
+	(*RpcListSeasonAroundResponse)(nil),     // 43: api.RpcListSeasonAroundResponse
+	(*TournamentPairing)(nil),               // 44: api.TournamentPairing
+	(*TournamentRound)(nil),                 // 45: api.TournamentRound
+	(*TournamentBracket)(nil),               // 46: api.TournamentBracket
+	(*RpcGetTournamentBracketResponse)(nil), // 47: api.RpcGetTournamentBracketResponse
+	(*Achievement)(nil),                     // 48: api.Achievement
+	(*RpcListAchievementsResponse)(nil),     // 49: api.RpcListAchievementsResponse
+	(*RewardCalendarDay)(nil),               // 50: api.RewardCalendarDay
+	(*RpcGetRewardCalendarResponse)(nil),    // 51: api.RpcGetRewardCalendarResponse
+	nil,                                     // 52: api.Start.MarksEntry
+	nil,                                     // 53: api.Start.SeriesScoreEntry
+	nil,                                     // 54: api.Start.TimeBanksEntry
+	nil,                                     // 55: api.Update.MarksEntry
+	nil,                                     // 56: api.Update.TimeBanksEntry
+	nil,                                     // 57: api.Done.SeriesScoreEntry
+	nil,                                     // 58: api.SeriesDone.SeriesScoreEntry
+	nil,                                     // 59: api.Replay.MarksEntry
+	nil,                                     // 60: api.AsyncGame.MarksEntry
+	nil,                                     // 61: api.Achievement.RewardEntry
+	nil,                                     // 62: api.RewardCalendarDay.RewardEntry
+	nil,                                     // 63: api.RpcGetRewardCalendarResponse.RepairCostEntry
+}
+var file_xoxoapi_proto_depIdxs = []int32{
+	0,  // 0: api.Start.board:type_name -> api.Mark
+	52, // 1: api.Start.marks:type_name -> api.Start.MarksEntry
+	0,  // 2: api.Start.mark:type_name -> api.Mark
+	53, // 3: api.Start.series_score:type_name -> api.Start.SeriesScoreEntry
+	54, // 4: api.Start.time_banks:type_name -> api.Start.TimeBanksEntry
+	0,  // 5: api.Update.board:type_name -> api.Mark
+	0,  // 6: api.Update.mark:type_name -> api.Mark
+	55, // 7: api.Update.marks:type_name -> api.Update.MarksEntry
+	56, // 8: api.Update.time_banks:type_name -> api.Update.TimeBanksEntry
+	0,  // 9: api.Done.board:type_name -> api.Mark
+	0,  // 10: api.Done.winner:type_name -> api.Mark
+	57, // 11: api.Done.series_score:type_name -> api.Done.SeriesScoreEntry
+	3,  // 12: api.Done.reason:type_name -> api.DoneReason
+	58, // 13: api.SeriesDone.series_score:type_name -> api.SeriesDone.SeriesScoreEntry
+	0,  // 14: api.ReplayMove.mark:type_name -> api.Mark
+	59, // 15: api.Replay.marks:type_name -> api.Replay.MarksEntry
+	13, // 16: api.Replay.moves:type_name -> api.ReplayMove
+	0,  // 17: api.Replay.board:type_name -> api.Mark
+	0,  // 18: api.Replay.winner:type_name -> api.Mark
+	3,  // 19: api.Replay.reason:type_name -> api.DoneReason
+	2,  // 20: api.RpcFindMatchRequest.difficulty:type_name -> api.Difficulty
+	18, // 21: api.Stats.fast_human:type_name -> api.StatsRecord
+	18, // 22: api.Stats.fast_ai:type_name -> api.StatsRecord
+	18, // 23: api.Stats.normal_human:type_name -> api.StatsRecord
+	18, // 24: api.Stats.normal_ai:type_name -> api.StatsRecord
+	0,  // 25: api.MatchHistoryEntry.mark:type_name -> api.Mark
+	4,  // 26: api.MatchHistoryEntry.result:type_name -> api.RoundResult
+	3,  // 27: api.MatchHistoryEntry.reason:type_name -> api.DoneReason
+	20, // 28: api.RpcListMatchHistoryResponse.entries:type_name -> api.MatchHistoryEntry
+	0,  // 29: api.AsyncGame.board:type_name -> api.Mark
+	60, // 30: api.AsyncGame.marks:type_name -> api.AsyncGame.MarksEntry
+	0,  // 31: api.AsyncGame.mark:type_name -> api.Mark
+	0,  // 32: api.AsyncGame.winner:type_name -> api.Mark
+	32, // 33: api.RpcListAsyncGamesResponse.games:type_name -> api.AsyncGame
+	38, // 34: api.Season.reward_tiers:type_name -> api.SeasonRewardTier
+	40, // 35: api.RpcGetSeasonStandingResponse.record:type_name -> api.SeasonRecord
+	40, // 36: api.RpcListSeasonAroundResponse.records:type_name -> api.SeasonRecord
+	44, // 37: api.TournamentRound.pairings:type_name -> api.TournamentPairing
+	45, // 38: api.TournamentBracket.rounds:type_name -> api.TournamentRound
+	46, // 39: api.RpcGetTournamentBracketResponse.bracket:type_name -> api.TournamentBracket
+	61, // 40: api.Achievement.reward:type_name -> api.Achievement.RewardEntry
+	48, // 41: api.RpcListAchievementsResponse.achievements:type_name -> api.Achievement
+	62, // 42: api.RewardCalendarDay.reward:type_name -> api.RewardCalendarDay.RewardEntry
+	50, // 43: api.RpcGetRewardCalendarResponse.days:type_name -> api.RewardCalendarDay
+	63, // 44: api.RpcGetRewardCalendarResponse.repair_cost:type_name -> api.RpcGetRewardCalendarResponse.RepairCostEntry
+	0,  // 45: api.Start.MarksEntry.value:type_name -> api.Mark
+	0,  // 46: api.Update.MarksEntry.value:type_name -> api.Mark
+	0,  // 47: api.Replay.MarksEntry.value:type_name -> api.Mark
+	0,  // 48: api.AsyncGame.MarksEntry.value:type_name -> api.Mark
+	49, // [49:49] is the sub-list for method output_type
+	49, // [49:49] is the sub-list for method input_type
+	49, // [49:49] is the sub-list for extension type_name
+	49, // [49:49] is the sub-list for extension extendee
+	0,  // [0:49] is the sub-list for field type_name
+}
+
+func init() { file_xoxoapi_proto_init() }
+func file_xoxoapi_proto_init() {
+	if File_xoxoapi_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_xoxoapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Start); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Update); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Done); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeriesDone); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrawResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rematch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pause); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplayMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Replay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcFindMatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcFindMatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetReplayRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Stats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchHistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListMatchHistoryRequest); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -661,8 +4906,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Update); i {
+		file_xoxoapi_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListMatchHistoryResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -673,8 +4918,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Done); i {
+		file_xoxoapi_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcCreatePrivateMatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -685,8 +4930,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Move); i {
+		file_xoxoapi_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcCreatePrivateMatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -697,8 +4942,8 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RpcFindMatchRequest); i {
+		file_xoxoapi_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcJoinByCodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -709,8 +4954,296 @@ func file_xoxoapi_proto_init() {
 				return nil
 			}
 		}
-		file_xoxoapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RpcFindMatchResponse); i {
+		file_xoxoapi_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcJoinByCodeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcChallengeFriendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcChallengeFriendResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcAnswerChallengeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcAcceptChallengeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AsyncGame); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcCreateAsyncGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetAsyncGameRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcAsyncMoveRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListAsyncGamesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListAsyncGamesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeasonRewardTier); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Season); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeasonRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetSeasonStandingResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListSeasonAroundRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListSeasonAroundResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TournamentPairing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TournamentRound); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TournamentBracket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetTournamentBracketResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Achievement); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcListAchievementsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RewardCalendarDay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_xoxoapi_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RpcGetRewardCalendarResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -727,8 +5260,8 @@ func file_xoxoapi_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_xoxoapi_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      5,
+			NumMessages:   59,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
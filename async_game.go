@@ -0,0 +1,479 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	notificationCodeAsyncTurn = 106
+	notificationCodeAsyncDone = 107
+
+	// Game state is owned by the system user, keyed by game ID, so neither player can change it directly.
+	asyncGameCollection = "async_game"
+	// Each player also gets an index object per game, so they can list their games.
+	asyncGameIndexCollection = "async_game_index"
+
+	asyncTurnDefaultHours = 24
+	asyncTurnMaxHours     = 7 * 24
+
+	// How many times to retry ending an expired game that lost a race with a concurrent write.
+	asyncGameWriteAttempts = 3
+)
+
+// An entry in a player's list of asynchronous games.
+type asyncGameIndex struct {
+	GameID string `json:"game_id"`
+}
+
+// Index keys count down over time, so listing the collection returns the most recent games first.
+func asyncGameIndexKey(gameID string, t time.Time) string {
+	return fmt.Sprintf("%019d.%s", math.MaxInt64-t.UnixNano(), gameID)
+}
+
+func newAsyncGameID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// Find the user playing the given mark.
+func asyncPlayerForMark(g *api.AsyncGame, mark api.Mark) string {
+	for userID, m := range g.Marks {
+		if m == mark {
+			return userID
+		}
+	}
+	return ""
+}
+
+// End the game if the player whose turn it is has run out of time. Returns true if the game was ended.
+func expireAsyncGame(g *api.AsyncGame, t time.Time) bool {
+	if g.Done || t.Unix() < g.Deadline {
+		return false
+	}
+	g.Done = true
+	g.Forfeit = true
+	g.Winner = opponentMark(g.Mark)
+	g.UpdateTime = t.Unix()
+	return true
+}
+
+// Read a game along with its storage object version, to use for OCC.
+func readAsyncGame(ctx context.Context, nk runtime.NakamaModule, unmarshaler *protojson.UnmarshalOptions, gameID string) (*api.AsyncGame, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: asyncGameCollection,
+		Key:        gameID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(objects) == 0 {
+		return nil, "", nil
+	}
+
+	g := &api.AsyncGame{}
+	if err := unmarshaler.Unmarshal([]byte(objects[0].GetValue()), g); err != nil {
+		return nil, "", err
+	}
+	return g, objects[0].GetVersion(), nil
+}
+
+// Write the game, only if it hasn't changed since it was read at the given version. Returns the new version.
+func writeAsyncGame(ctx context.Context, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, g *api.AsyncGame, version string) (string, error) {
+	value, err := marshaler.Marshal(g)
+	if err != nil {
+		return "", err
+	}
+	acks, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      asyncGameCollection,
+		Key:             g.GameId,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  0, // No client read, games are fetched through the RPCs.
+		PermissionWrite: 0, // No client write.
+	}})
+	if err != nil {
+		return "", err
+	}
+	return acks[0].GetVersion(), nil
+}
+
+// Let the players know the game needs their attention: the player whose turn it is, or both players once it's over.
+func notifyAsyncGame(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, g *api.AsyncGame, senderID string) {
+	content := map[string]interface{}{
+		"game_id":  g.GameId,
+		"game":     g.Game,
+		"deadline": g.Deadline,
+	}
+
+	var notifications []*runtime.NotificationSend
+	if g.Done {
+		for userID := range g.Marks {
+			if userID == senderID {
+				continue
+			}
+			notifications = append(notifications, &runtime.NotificationSend{
+				Code:       notificationCodeAsyncDone,
+				Content:    content,
+				Persistent: true,
+				Sender:     senderID,
+				Subject:    "Your game is over.",
+				UserID:     userID,
+			})
+		}
+	} else if userID := asyncPlayerForMark(g, g.Mark); userID != senderID {
+		notifications = append(notifications, &runtime.NotificationSend{
+			Code:       notificationCodeAsyncTurn,
+			Content:    content,
+			Persistent: true,
+			Sender:     senderID,
+			Subject:    "It's your turn!",
+			UserID:     userID,
+		})
+	}
+
+	if len(notifications) == 0 {
+		return
+	}
+	if err := nk.NotificationsSend(ctx, notifications); err != nil {
+		logger.Error("NotificationsSend error: %v", err)
+	}
+}
+
+// Read a game the user is playing, ending it first if a player has run out of time.
+func readPlayerAsyncGame(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, userID, gameID string, t time.Time) (*api.AsyncGame, string, error) {
+	for attempt := 1; ; attempt++ {
+		g, version, err := readAsyncGame(ctx, nk, unmarshaler, gameID)
+		if err != nil {
+			logger.Error("error reading game: %v", err)
+			return nil, "", errInternalError
+		}
+		if g == nil {
+			return nil, "", errAsyncGameNotFound
+		}
+		if _, ok := g.Marks[userID]; !ok {
+			return nil, "", errAsyncGameNotFound
+		}
+
+		if !expireAsyncGame(g, t) {
+			return g, version, nil
+		}
+		newVersion, err := writeAsyncGame(ctx, nk, marshaler, g, version)
+		if err != nil {
+			if isVersionConflict(err) && attempt < asyncGameWriteAttempts {
+				// Someone else got there first, use their version of the game instead.
+				continue
+			}
+			logger.Error("error ending expired game: %v", err)
+			return nil, "", errInternalError
+		}
+		notifyAsyncGame(ctx, logger, nk, g, "")
+		return g, newVersion, nil
+	}
+}
+
+// Start an asynchronous game against a friend. The user creating the game plays first.
+func rpcCreateAsyncGame(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcCreateAsyncGameRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+		if request.UserId == "" || request.UserId == userID {
+			return "", errNotFriends
+		}
+
+		rules, dimensions, err := resolveMatchSettings(request.Game, request.Width, request.Height, request.WinLength, 0)
+		if err != nil {
+			return "", err
+		}
+
+		turnHours := int(request.TurnHours)
+		if turnHours == 0 {
+			turnHours = asyncTurnDefaultHours
+		}
+		if turnHours < 1 || turnHours > asyncTurnMaxHours {
+			return "", errInvalidTurnHours
+		}
+
+		friends, err := areFriends(ctx, nk, userID, request.UserId)
+		if err != nil {
+			logger.Error("FriendsList error: %v", err)
+			return "", errInternalError
+		}
+		if !friends {
+			return "", errNotFriends
+		}
+
+		gameID, err := newAsyncGameID()
+		if err != nil {
+			logger.Error("error generating game ID: %v", err)
+			return "", errInternalError
+		}
+
+		t := time.Now()
+		turn := time.Duration(turnHours) * time.Hour
+		g := &api.AsyncGame{
+			GameId:    gameID,
+			Game:      rules.Name(),
+			Width:     int32(dimensions.Width),
+			Height:    int32(dimensions.Height),
+			WinLength: int32(dimensions.WinLength),
+			Board:     rules.NewBoard(dimensions),
+			Marks: map[string]api.Mark{
+				userID:         api.Mark_MARK_X,
+				request.UserId: api.Mark_MARK_O,
+			},
+			Mark:       api.Mark_MARK_X,
+			Deadline:   t.Add(turn).Unix(),
+			TurnSec:    int64(turn.Seconds()),
+			CreateTime: t.Unix(),
+			UpdateTime: t.Unix(),
+		}
+		value, err := marshaler.Marshal(g)
+		if err != nil {
+			logger.Error("error encoding game: %v", err)
+			return "", errMarshal
+		}
+		index, err := json.Marshal(&asyncGameIndex{GameID: gameID})
+		if err != nil {
+			logger.Error("Marshal error: %v", err)
+			return "", errMarshal
+		}
+
+		writes := []*runtime.StorageWrite{{
+			Collection:      asyncGameCollection,
+			Key:             gameID,
+			Value:           string(value),
+			Version:         "*",
+			PermissionRead:  0, // No client read, games are fetched through the RPCs.
+			PermissionWrite: 0, // No client write.
+		}}
+		for _, playerID := range []string{userID, request.UserId} {
+			writes = append(writes, &runtime.StorageWrite{
+				Collection:      asyncGameIndexCollection,
+				Key:             asyncGameIndexKey(gameID, t),
+				UserID:          playerID,
+				Value:           string(index),
+				PermissionRead:  1,
+				PermissionWrite: 0, // No client write.
+			})
+		}
+		if _, err := nk.StorageWrite(ctx, writes); err != nil {
+			logger.Error("StorageWrite error: %v", err)
+			return "", errInternalError
+		}
+
+		return string(value), nil
+	}
+}
+
+// Fetch an asynchronous game the user is playing.
+func rpcGetAsyncGame(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcGetAsyncGameRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		g, _, err := readPlayerAsyncGame(ctx, logger, nk, marshaler, unmarshaler, userID, request.GameId, time.Now())
+		if err != nil {
+			return "", err
+		}
+
+		response, err := marshaler.Marshal(g)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Make a move in an asynchronous game, validated with the same rules as realtime matches.
+func rpcAsyncMove(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcAsyncMoveRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		t := time.Now()
+		g, version, err := readPlayerAsyncGame(ctx, logger, nk, marshaler, unmarshaler, userID, request.GameId, t)
+		if err != nil {
+			return "", err
+		}
+		if g.Done {
+			return "", errAsyncGameOver
+		}
+		mark := g.Marks[userID]
+		if g.Mark != mark {
+			return "", errNotYourTurn
+		}
+
+		rules, ok := games[g.Game]
+		if !ok {
+			logger.Error("unknown game %q", g.Game)
+			return "", errInternalError
+		}
+		dimensions := BoardDimensions{Width: int(g.Width), Height: int(g.Height), WinLength: int(g.WinLength)}
+
+		position, err := rules.ApplyMove(dimensions, g.Board, mark, int(request.Position))
+		if err != nil {
+			if errors.Is(err, errIllegalMove) {
+				return "", errAsyncIllegalMove
+			}
+			logger.Error("error applying move: %v", err)
+			return "", errInternalError
+		}
+		g.Mark = rules.NextTurn(dimensions, g.Board, mark)
+		g.Deadline = t.Add(time.Duration(g.TurnSec) * time.Second).Unix()
+		g.UpdateTime = t.Unix()
+		if done, winner, winnerPositions := rules.Result(dimensions, g.Board, position); done {
+			g.Done = true
+			g.Winner = winner
+			g.WinnerPositions = winnerPositions
+		}
+
+		// The version makes sure a move made at the same time, for example from another device, can't also apply.
+		if _, err := writeAsyncGame(ctx, nk, marshaler, g, version); err != nil {
+			if isVersionConflict(err) {
+				return "", errAsyncGameChanged
+			}
+			logger.Error("error writing game: %v", err)
+			return "", errInternalError
+		}
+
+		notifyAsyncGame(ctx, logger, nk, g, userID)
+
+		response, err := marshaler.Marshal(g)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// List the asynchronous games the user is playing or has played, most recent first.
+func rpcListAsyncGames(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListAsyncGamesRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		limit := int(request.Limit)
+		if limit == 0 {
+			limit = matchHistoryDefaultLimit
+		}
+		if limit < 1 || limit > matchHistoryMaxLimit {
+			return "", errInvalidLimit
+		}
+
+		objects, cursor, err := nk.StorageList(ctx, "", userID, asyncGameIndexCollection, limit, request.Cursor)
+		if err != nil {
+			logger.Error("StorageList error: %v", err)
+			return "", errInternalError
+		}
+
+		gameIDs := make([]string, 0, len(objects))
+		reads := make([]*runtime.StorageRead, 0, len(objects))
+		for _, object := range objects {
+			index := &asyncGameIndex{}
+			if err := json.Unmarshal([]byte(object.GetValue()), index); err != nil {
+				logger.Error("Unmarshal error: %v", err)
+				return "", errUnmarshal
+			}
+			gameIDs = append(gameIDs, index.GameID)
+			reads = append(reads, &runtime.StorageRead{Collection: asyncGameCollection, Key: index.GameID})
+		}
+
+		byID := make(map[string]*api.AsyncGame, len(reads))
+		if len(reads) > 0 {
+			gameObjects, err := nk.StorageRead(ctx, reads)
+			if err != nil {
+				logger.Error("StorageRead error: %v", err)
+				return "", errInternalError
+			}
+			for _, object := range gameObjects {
+				g := &api.AsyncGame{}
+				if err := unmarshaler.Unmarshal([]byte(object.GetValue()), g); err != nil {
+					logger.Error("Unmarshal error: %v", err)
+					return "", errUnmarshal
+				}
+				byID[g.GameId] = g
+			}
+		}
+
+		// Show games that have run out of time as over, they're saved as such the next time they're fetched.
+		t := time.Now()
+		response := &api.RpcListAsyncGamesResponse{Cursor: cursor}
+		for _, gameID := range gameIDs {
+			if g, ok := byID[gameID]; ok {
+				expireAsyncGame(g, t)
+				response.Games = append(response.Games, g)
+			}
+		}
+
+		out, err := marshaler.Marshal(response)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(out), nil
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+// The largest board any game can be played on.
+const maxBoardSize = 19
+
+// The directions a line of marks can run in: horizontal, vertical, and both diagonals.
+var lineDirections = [][2]int{
+	{1, 0},
+	{0, 1},
+	{1, 1},
+	{1, -1},
+}
+
+// BoardDimensions describes the size of the board and how many marks in a row are needed to win.
+// Positions are numbered row by row, starting from 0 in the top left corner.
+type BoardDimensions struct {
+	Width     int
+	Height    int
+	WinLength int
+}
+
+// Check the board is within the given size range, and the win length fits on it.
+func (d BoardDimensions) validate(minSize, maxSize, minWinLength int) error {
+	if d.Width < minSize || d.Width > maxSize || d.Height < minSize || d.Height > maxSize {
+		return fmt.Errorf("board must be between %dx%d and %dx%d", minSize, minSize, maxSize, maxSize)
+	}
+	if d.WinLength < minWinLength || d.WinLength > max(d.Width, d.Height) {
+		return fmt.Errorf("win length must be between %d and %d", minWinLength, max(d.Width, d.Height))
+	}
+	return nil
+}
+
+func (d BoardDimensions) Size() int {
+	return d.Width * d.Height
+}
+
+// Check if the given column and row are on the board.
+func (d BoardDimensions) contains(x, y int) bool {
+	return x >= 0 && x < d.Width && y >= 0 && y < d.Height
+}
+
+// WinningLine checks if the mark at the given position completes a line of at least WinLength marks, and returns
+// the positions in that line if it does.
+func (d BoardDimensions) WinningLine(board []api.Mark, position int) []int32 {
+	mark := board[position]
+	if mark == api.Mark_MARK_UNSPECIFIED {
+		return nil
+	}
+
+	x, y := position%d.Width, position/d.Width
+	for _, dir := range lineDirections {
+		line := []int32{int32(position)}
+		// Walk away from the position in both directions along the line for as long as the mark matches.
+		for _, sign := range []int{-1, 1} {
+			for step := 1; ; step++ {
+				nx, ny := x+sign*step*dir[0], y+sign*step*dir[1]
+				if !d.contains(nx, ny) || board[ny*d.Width+nx] != mark {
+					break
+				}
+				line = append(line, int32(ny*d.Width+nx))
+			}
+		}
+		if len(line) >= d.WinLength {
+			slices.Sort(line)
+			return line
+		}
+	}
+
+	return nil
+}
+
+// Read an integer match parameter that may have been passed as any numeric type.
+func intParam(params map[string]interface{}, key string, defaultValue int) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return defaultValue
+	}
+}
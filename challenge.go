@@ -0,0 +1,384 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	nkapi "github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	notificationCodeChallenge          = 102
+	notificationCodeChallengeAccepted  = 103
+	notificationCodeChallengeDeclined  = 104
+	notificationCodeChallengeCancelled = 105
+
+	// Pending challenges are owned by the challenger, keyed by the user ID of the friend they challenged.
+	challengeCollection = "challenge"
+
+	// How long a friend has to answer a challenge.
+	challengeTTLSec = 300
+	// How long both users have to join the match once a challenge is accepted.
+	challengeReservationSec = 120
+
+	friendStateMutual = 0
+	friendsPageSize   = 1000
+
+	challengesPageSize = 100
+)
+
+// A pending challenge storage object.
+type challenge struct {
+	Game         string `json:"game"`
+	Fast         bool   `json:"fast"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	WinLength    int    `json:"win_length"`
+	BestOf       int    `json:"best_of"`
+	TimeBankSec  int    `json:"time_bank_sec"`
+	IncrementSec int    `json:"increment_sec"`
+	ExpireUnix   int64  `json:"expire_unix"` // The time the challenge expires in UNIX time.
+}
+
+// Check if the users are mutual friends.
+func areFriends(ctx context.Context, nk runtime.NakamaModule, userID, friendID string) (bool, error) {
+	state := friendStateMutual
+	cursor := ""
+	for {
+		friends, next, err := nk.FriendsList(ctx, userID, friendsPageSize, &state, cursor)
+		if err != nil {
+			return false, err
+		}
+		for _, friend := range friends {
+			if friend.GetUser().GetId() == friendID {
+				return true, nil
+			}
+		}
+		if next == "" {
+			return false, nil
+		}
+		cursor = next
+	}
+}
+
+// Read the pending challenge from the challenger to the user, along with its storage object version. Expired
+// challenges are treated as if they don't exist.
+func readChallenge(ctx context.Context, nk runtime.NakamaModule, challengerID, userID string) (*challenge, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: challengeCollection,
+		Key:        userID,
+		UserID:     challengerID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(objects) == 0 {
+		return nil, "", nil
+	}
+
+	c := &challenge{}
+	if err := json.Unmarshal([]byte(objects[0].GetValue()), c); err != nil {
+		return nil, "", err
+	}
+	if time.Now().Unix() >= c.ExpireUnix {
+		return nil, "", nil
+	}
+	return c, objects[0].GetVersion(), nil
+}
+
+// Write a pending challenge from the challenger to the user, replacing any earlier one unless a version is given.
+func writeChallenge(ctx context.Context, nk runtime.NakamaModule, challengerID, userID string, c *challenge, version string) error {
+	value, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      challengeCollection,
+		Key:             userID,
+		UserID:          challengerID,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  1,
+		PermissionWrite: 0, // No client write.
+	}})
+	return err
+}
+
+// Remove a challenge once it's answered. The version makes sure it's only answered once.
+func deleteChallenge(ctx context.Context, nk runtime.NakamaModule, challengerID, userID, version string) error {
+	return nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+		Collection: challengeCollection,
+		Key:        userID,
+		UserID:     challengerID,
+		Version:    version,
+	}})
+}
+
+// Cancel all the challenges the user has sent, letting the friends they challenged know. Challenges that have already
+// expired are removed without a notification.
+func cancelChallenges(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, challengerID string) {
+	var objects []*nkapi.StorageObject
+	cursor := ""
+	for {
+		page, next, err := nk.StorageList(ctx, "", challengerID, challengeCollection, challengesPageSize, cursor)
+		if err != nil {
+			logger.WithField("err", err).Error("nk.StorageList error.")
+			return
+		}
+		objects = append(objects, page...)
+		if next == "" || len(page) == 0 {
+			break
+		}
+		cursor = next
+	}
+	if len(objects) == 0 {
+		return
+	}
+
+	now := time.Now().Unix()
+	deletes := make([]*runtime.StorageDelete, 0, len(objects))
+	notifications := make([]*runtime.NotificationSend, 0, len(objects))
+	for _, object := range objects {
+		deletes = append(deletes, &runtime.StorageDelete{
+			Collection: challengeCollection,
+			Key:        object.GetKey(),
+			UserID:     challengerID,
+		})
+
+		c := &challenge{}
+		if err := json.Unmarshal([]byte(object.GetValue()), c); err != nil {
+			logger.WithField("err", err).Error("json.Unmarshal error.")
+			continue
+		}
+		if now >= c.ExpireUnix {
+			continue
+		}
+		notifications = append(notifications, &runtime.NotificationSend{
+			Code: notificationCodeChallengeCancelled,
+			Content: map[string]interface{}{
+				"challenger_id": challengerID,
+			},
+			Persistent: true,
+			Sender:     challengerID,
+			Subject:    "A challenge was cancelled.",
+			UserID:     object.GetKey(),
+		})
+	}
+
+	if err := nk.StorageDelete(ctx, deletes); err != nil {
+		logger.WithField("err", err).Error("nk.StorageDelete error.")
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := nk.NotificationsSend(ctx, notifications); err != nil {
+		logger.WithField("err", err).Error("nk.NotificationsSend error.")
+	}
+}
+
+// Challenge a friend to a private match. Sending another challenge to the same friend replaces the previous one.
+func rpcChallengeFriend(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcChallengeFriendRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+		if request.UserId == "" || request.UserId == userID {
+			return "", errNotFriends
+		}
+
+		rules, dimensions, err := resolveMatchSettings(request.Game, request.Width, request.Height, request.WinLength, request.BestOf)
+		if err != nil {
+			return "", err
+		}
+		if err := validateTimeControl(int(request.TimeBankSec), int(request.IncrementSec)); err != nil {
+			return "", err
+		}
+
+		friends, err := areFriends(ctx, nk, userID, request.UserId)
+		if err != nil {
+			logger.Error("FriendsList error: %v", err)
+			return "", errInternalError
+		}
+		if !friends {
+			return "", errNotFriends
+		}
+
+		c := &challenge{
+			Game:         rules.Name(),
+			Fast:         request.Fast,
+			Width:        dimensions.Width,
+			Height:       dimensions.Height,
+			WinLength:    dimensions.WinLength,
+			BestOf:       int(request.BestOf),
+			TimeBankSec:  int(request.TimeBankSec),
+			IncrementSec: int(request.IncrementSec),
+			ExpireUnix:   time.Now().Add(challengeTTLSec * time.Second).Unix(),
+		}
+		if err := writeChallenge(ctx, nk, userID, request.UserId, c, ""); err != nil {
+			logger.Error("error writing challenge: %v", err)
+			return "", errInternalError
+		}
+
+		if err := nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
+			Code: notificationCodeChallenge,
+			Content: map[string]interface{}{
+				"challenger_id": userID,
+				"game":          c.Game,
+				"fast":          c.Fast,
+				"best_of":       c.BestOf,
+				"time_bank_sec": c.TimeBankSec,
+				"increment_sec": c.IncrementSec,
+				"expires_at":    c.ExpireUnix,
+			},
+			Persistent: true,
+			Sender:     userID,
+			Subject:    "You've been challenged to a match!",
+			UserID:     request.UserId,
+		}}); err != nil {
+			logger.Error("NotificationsSend error: %v", err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcChallengeFriendResponse{ExpiresAt: c.ExpireUnix})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Accept a challenge, creating a private match with spaces reserved for both users.
+func rpcAcceptChallenge(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcAnswerChallengeRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		c, version, err := readChallenge(ctx, nk, request.ChallengerId, userID)
+		if err != nil {
+			logger.Error("error reading challenge: %v", err)
+			return "", errInternalError
+		}
+		if c == nil {
+			return "", errChallengeNotFound
+		}
+		if err := deleteChallenge(ctx, nk, request.ChallengerId, userID, version); err != nil {
+			// Already answered, or replaced by a new challenge.
+			return "", errChallengeNotFound
+		}
+
+		matchID, code, err := createPrivateMatch(ctx, logger, nk, c.Game, map[string]interface{}{
+			"fast": c.Fast, "best_of": c.BestOf,
+			"width": c.Width, "height": c.Height, "win_length": c.WinLength,
+			"time_bank_sec": c.TimeBankSec, "increment_sec": c.IncrementSec,
+			"reserved": []string{request.ChallengerId, userID}, "reservation_sec": challengeReservationSec})
+		if err != nil {
+			// Put the challenge back so it can still be answered, unless the challenger has sent a new one.
+			if err := writeChallenge(ctx, nk, request.ChallengerId, userID, c, "*"); err != nil {
+				logger.Error("error restoring challenge: %v", err)
+			}
+			return "", err
+		}
+
+		if err := nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
+			Code: notificationCodeChallengeAccepted,
+			Content: map[string]interface{}{
+				"user_id":  userID,
+				"match_id": matchID,
+				"code":     code,
+			},
+			Persistent: true,
+			Sender:     userID,
+			Subject:    "Your challenge was accepted!",
+			UserID:     request.ChallengerId,
+		}}); err != nil {
+			logger.Error("NotificationsSend error: %v", err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(&api.RpcAcceptChallengeResponse{MatchId: matchID, Code: code})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Decline a challenge, letting the challenger know.
+func rpcDeclineChallenge(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcAnswerChallengeRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+
+		c, version, err := readChallenge(ctx, nk, request.ChallengerId, userID)
+		if err != nil {
+			logger.Error("error reading challenge: %v", err)
+			return "", errInternalError
+		}
+		if c == nil {
+			return "", errChallengeNotFound
+		}
+		if err := deleteChallenge(ctx, nk, request.ChallengerId, userID, version); err != nil {
+			return "", errChallengeNotFound
+		}
+
+		if err := nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
+			Code: notificationCodeChallengeDeclined,
+			Content: map[string]interface{}{
+				"user_id": userID,
+			},
+			Persistent: true,
+			Sender:     userID,
+			Subject:    "Your challenge was declined.",
+			UserID:     request.ChallengerId,
+		}}); err != nil {
+			logger.Error("NotificationsSend error: %v", err)
+			return "", errInternalError
+		}
+
+		return "{}", nil
+	}
+}
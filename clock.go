@@ -0,0 +1,70 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+const (
+	maxTimeBankSec  = 3600
+	maxIncrementSec = 60
+)
+
+// Check a chess clock time control. A time bank of 0 means fixed per-move deadlines instead, with no increment.
+func validateTimeControl(timeBankSec, incrementSec int) error {
+	if timeBankSec < 0 || timeBankSec > maxTimeBankSec || incrementSec < 0 || incrementSec > maxIncrementSec {
+		return errInvalidTimeControl
+	}
+	if timeBankSec == 0 && incrementSec > 0 {
+		return errInvalidTimeControl
+	}
+	return nil
+}
+
+// Fill every player's time bank for a new round, if the match uses a chess clock.
+func (s *MatchState) resetTimeBanks() {
+	s.timeBankTicks = nil
+	if s.label.TimeBank == 0 {
+		return
+	}
+	s.timeBankTicks = make(map[string]int64, len(s.marks))
+	for userID := range s.marks {
+		s.timeBankTicks[userID] = int64(s.label.TimeBank * tickRate)
+	}
+}
+
+// Work out how long the player to move has. With a chess clock, the player that just moved banks what they had left
+// plus the increment, and the clock moves on to the player to move. Otherwise every move has the same deadline.
+func (s *MatchState) nextDeadlineTicks(movedUserID string) int64 {
+	if s.timeBankTicks == nil {
+		return calculateDeadlineTicks(s.label)
+	}
+	if movedUserID != "" {
+		s.timeBankTicks[movedUserID] = s.deadlineRemainingTicks + int64(s.label.Increment*tickRate)
+	}
+	return s.timeBankTicks[s.userIDForMark(s.mark)]
+}
+
+// Time left in each player's time bank in milliseconds, counting down the clock of the player to move.
+func (s *MatchState) timeBanks() map[string]int64 {
+	if s.timeBankTicks == nil {
+		return nil
+	}
+	banks := make(map[string]int64, len(s.timeBankTicks))
+	for userID, ticks := range s.timeBankTicks {
+		banks[userID] = ticks * 1000 / tickRate
+	}
+	if s.playing {
+		banks[s.userIDForMark(s.mark)] = s.deadlineRemainingTicks * 1000 / tickRate
+	}
+	return banks
+}
@@ -17,122 +17,350 @@ package main
 import (
 	"context"
 	"database/sql"
+	_ "embed"
 	"encoding/json"
-	"github.com/heroiclabs/nakama-common/runtime"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const notificationCodeDailyReward = 1001
+
+// The rewards for each day of a login streak, loaded when the module starts.
+//
+//go:embed reward_calendar.json
+var rewardCalendarJSON []byte
+
+type rewardCalendar struct {
+	// One entry per day. After the last day the calendar starts again from the first.
+	Days []*calendarDay `json:"days"`
+	// Currencies taken from the wallet to keep a broken streak going.
+	RepairCost map[string]int64 `json:"repair_cost"`
+	// How many days in a row can be missed and still repaired. 0 turns repairs off.
+	RepairMaxMissedDays int64 `json:"repair_max_missed_days"`
+}
+
+type calendarDay struct {
+	Reward map[string]int64 `json:"reward"`
+}
+
 // A daily reward storage object for a user.
 type dailyReward struct {
-	LastClaimUnix int64 `json:"last_claim_unix"` // The last time the user claimed the reward in UNIX time.
+	LastClaimUnix int64 `json:"last_claim_unix"`    // The last time the user claimed the reward in UNIX time.
+	Streak        int64 `json:"streak"`             // Days in a row the user has claimed the reward, up to the last claim.
+	Repaired      bool  `json:"repaired,omitempty"` // The streak was repaired and hasn't been claimed since.
 }
 
-// Fetch daily reward for the player. If a new reward is available send it to the player over a notification.
-func rpcRewards(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
-	userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
-	if !ok {
-		return "", errNoUserIdFound
+func loadRewardCalendar(raw []byte) (*rewardCalendar, error) {
+	calendar := &rewardCalendar{}
+	if err := json.Unmarshal(raw, calendar); err != nil {
+		return nil, err
+	}
+
+	if len(calendar.Days) == 0 {
+		return nil, errors.New("at least one day is required")
+	}
+	for i, day := range calendar.Days {
+		if len(day.Reward) == 0 {
+			return nil, fmt.Errorf("day %d must have a reward", i+1)
+		}
+		for currency, amount := range day.Reward {
+			if amount <= 0 {
+				return nil, fmt.Errorf("day %d reward for %q must be positive", i+1, currency)
+			}
+		}
+	}
+	if calendar.RepairMaxMissedDays < 0 {
+		return nil, errors.New("repair max missed days must not be negative")
 	}
+	for currency, amount := range calendar.RepairCost {
+		if amount < 0 {
+			return nil, fmt.Errorf("repair cost for %q must not be negative", currency)
+		}
+	}
+	return calendar, nil
+}
 
-	if len(payload) > 0 {
-		return "", errNoInputAllowed
+// Days since the Unix epoch of the local calendar date of t, so a new day starts at local midnight.
+func dayNumber(t time.Time) int64 {
+	t = t.In(time.Local)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// Whole days the user has missed between their last claim and today.
+func (r *dailyReward) missedDays(t time.Time) int64 {
+	if r.LastClaimUnix == 0 {
+		return 0
 	}
+	return dayNumber(t) - dayNumber(time.Unix(r.LastClaimUnix, 0)) - 1
+}
 
+func (r *dailyReward) claimedToday(t time.Time) bool {
+	return r.LastClaimUnix != 0 && r.missedDays(t) < 0
+}
+
+// The streak as it stands today, which is 0 once a day has been missed.
+func (r *dailyReward) currentStreak(t time.Time) int64 {
+	if r.missedDays(t) > 0 {
+		return 0
+	}
+	return r.Streak
+}
+
+// The calendar day a claim extending this streak is for, starting from 1.
+func (c *rewardCalendar) day(streak int64) int64 {
+	return streak%int64(len(c.Days)) + 1
+}
+
+// A streak can only be repaired once between claims, so a repair is always followed by a claim.
+func (c *rewardCalendar) repairable(reward *dailyReward, t time.Time) bool {
+	missed := reward.missedDays(t)
+	return reward.Streak > 0 && !reward.Repaired && missed > 0 && missed <= c.RepairMaxMissedDays
+}
+
+func readDailyReward(ctx context.Context, nk runtime.NakamaModule, userID string) (*dailyReward, string, error) {
 	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
 		Collection: "reward",
 		Key:        "daily",
 		UserID:     userID,
 	}})
 	if err != nil {
-		logger.Error("StorageRead error: %v", err)
-		return "", errInternalError
+		return nil, "", err
 	}
 
-	dailyReward := &dailyReward{
-		LastClaimUnix: 0,
+	reward := &dailyReward{}
+	if len(objects) == 0 {
+		return reward, "*", nil
 	}
-	for _, object := range objects {
-		switch object.GetKey() {
-		case "daily":
-			if err := json.Unmarshal([]byte(object.GetValue()), dailyReward); err != nil {
-				logger.Error("Unmarshal error: %v", err)
-				return "", errUnmarshal
-			}
-			break
-		}
+	if err := json.Unmarshal([]byte(objects[0].GetValue()), reward); err != nil {
+		return nil, "", err
 	}
+	return reward, objects[0].GetVersion(), nil
+}
 
-	var resp struct {
-		CoinsReceived int64 `json:"coins_received"`
+func dailyRewardWrite(userID string, reward *dailyReward, version string) (*runtime.StorageWrite, error) {
+	value, err := json.Marshal(reward)
+	if err != nil {
+		return nil, err
 	}
-	resp.CoinsReceived = int64(0)
+	return &runtime.StorageWrite{
+		Collection:      "reward",
+		Key:             "daily",
+		PermissionRead:  1,
+		PermissionWrite: 0, // No client write.
+		Value:           string(value),
+		Version:         version, // Use OCC to prevent concurrent writes.
+		UserID:          userID,
+	}, nil
+}
 
-	// If last claimed is before the new day grant a new reward!
-	t := time.Now()
-	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
-	if time.Unix(dailyReward.LastClaimUnix, 0).Before(midnight) {
-		resp.CoinsReceived = 500
+func (c *rewardCalendar) response(reward *dailyReward, t time.Time) *api.RpcGetRewardCalendarResponse {
+	days := make([]*api.RewardCalendarDay, 0, len(c.Days))
+	for i, day := range c.Days {
+		days = append(days, &api.RewardCalendarDay{Day: int32(i + 1), Reward: day.Reward})
+	}
+	streak := reward.currentStreak(t)
+	claimedToday := reward.claimedToday(t)
+	nextClaimTime := t.Unix()
+	if claimedToday {
+		local := t.In(time.Local)
+		nextClaimTime = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.Local).Unix()
+	}
+	return &api.RpcGetRewardCalendarResponse{
+		Days:          days,
+		Streak:        int32(streak),
+		NextDay:       int32(c.day(streak)),
+		ClaimedToday:  claimedToday,
+		NextClaimTime: nextClaimTime,
+		Repairable:    c.repairable(reward, t),
+		RepairCost:    c.RepairCost,
+	}
+}
 
-		// Update player wallet.
-		changeset := map[string]int64{
-			"coins": resp.CoinsReceived,
+// Fetch daily reward for the player. If a new reward is available send it to the player over a notification.
+func rpcRewards(calendar *rewardCalendar, achievements achievementDefinitions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
 		}
-		if _, _, err := nk.WalletUpdate(ctx, userID, changeset, map[string]interface{}{}, false); err != nil {
-			logger.Error("WalletUpdate error: %v", err)
-			return "", errInternalError
+
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
 		}
 
-		err := nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
-			Code: 1001,
-			Content: map[string]interface{}{
-				"coins": changeset["coins"],
-			},
-			Persistent: true,
-			Sender:     "", // Server sent.
-			Subject:    "You've received your daily reward!",
-			UserID:     userID,
-		}})
+		dailyReward, version, err := readDailyReward(ctx, nk, userID)
 		if err != nil {
-			logger.Error("NotificationsSend error: %v", err)
+			logger.Error("StorageRead error: %v", err)
 			return "", errInternalError
 		}
 
-		dailyReward.LastClaimUnix = time.Now().Unix()
+		var resp struct {
+			CoinsReceived int64            `json:"coins_received"`
+			Reward        map[string]int64 `json:"reward,omitempty"`
+			Streak        int64            `json:"streak"`
+			Day           int64            `json:"day,omitempty"`
+		}
+
+		// If last claimed is before the new day grant a new reward!
+		t := time.Now()
+		if dailyReward.claimedToday(t) {
+			resp.Streak = dailyReward.Streak
+		} else {
+			streak := dailyReward.currentStreak(t)
+			day := calendar.day(streak)
+			changeset := calendar.Days[day-1].Reward
 
-		object, err := json.Marshal(dailyReward)
+			dailyReward.LastClaimUnix = t.Unix()
+			dailyReward.Streak = streak + 1
+			dailyReward.Repaired = false
+
+			write, err := dailyRewardWrite(userID, dailyReward, version)
+			if err != nil {
+				logger.Error("Marshal error: %v", err)
+				return "", errInternalError
+			}
+
+			// Update daily reward storage object and player wallet together.
+			wallets := []*runtime.WalletUpdate{{
+				UserID:    userID,
+				Changeset: changeset,
+				Metadata:  map[string]interface{}{"streak": dailyReward.Streak},
+			}}
+			if _, _, err := nk.MultiUpdate(ctx, nil, []*runtime.StorageWrite{write}, nil, wallets, true); err != nil {
+				logger.Error("MultiUpdate error: %v", err)
+				return "", errInternalError
+			}
+			achievements.record(ctx, logger, nk, userID, achievementEventDailyReward)
+
+			resp.CoinsReceived = changeset["coins"]
+			resp.Reward = changeset
+			resp.Streak = dailyReward.Streak
+			resp.Day = day
+
+			err = nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
+				Code: notificationCodeDailyReward,
+				Content: map[string]interface{}{
+					"coins":  changeset["coins"],
+					"reward": changeset,
+					"streak": dailyReward.Streak,
+					"day":    day,
+				},
+				Persistent: true,
+				Sender:     "", // Server sent.
+				Subject:    "You've received your daily reward!",
+				UserID:     userID,
+			}})
+			if err != nil {
+				logger.Error("NotificationsSend error: %v", err)
+			}
+		}
+
+		out, err := json.Marshal(resp)
 		if err != nil {
 			logger.Error("Marshal error: %v", err)
-			return "", errInternalError
+			return "", errMarshal
+		}
+
+		logger.Debug("rpcRewards resp: %v", string(out))
+		return string(out), nil
+	}
+}
+
+// Show the reward calendar and where the user is in it, without claiming anything.
+func rpcGetRewardCalendar(marshaler *protojson.MarshalOptions, calendar *rewardCalendar) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
 		}
 
-		version := ""
-		if len(objects) > 0 {
-			// Use OCC to prevent concurrent writes.
-			version = objects[0].GetVersion()
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
 		}
 
-		// Update daily reward storage object for user.
-		_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{{
-			Collection:      "reward",
-			Key:             "daily",
-			PermissionRead:  1,
-			PermissionWrite: 0, // No client write.
-			Value:           string(object),
-			Version:         version,
-			UserID:          userID,
-		}})
+		dailyReward, _, err := readDailyReward(ctx, nk, userID)
 		if err != nil {
-			logger.Error("StorageWrite error: %v", err)
+			logger.Error("StorageRead error: %v", err)
 			return "", errInternalError
 		}
-	}
 
-	out, err := json.Marshal(resp)
-	if err != nil {
-		logger.Error("Marshal error: %v", err)
-		return "", errMarshal
+		response, err := marshaler.Marshal(calendar.response(dailyReward, time.Now()))
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
 	}
+}
 
-	logger.Debug("rpcRewards resp: %v", string(out))
-	return string(out), nil
+// Pay the repair cost to keep a streak going after missing a day. The user still has to claim today's reward.
+func rpcRepairRewardStreak(marshaler *protojson.MarshalOptions, calendar *rewardCalendar) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
+		}
+
+		dailyReward, version, err := readDailyReward(ctx, nk, userID)
+		if err != nil {
+			logger.Error("StorageRead error: %v", err)
+			return "", errInternalError
+		}
+
+		t := time.Now()
+		if !calendar.repairable(dailyReward, t) {
+			return "", errStreakNotRepairable
+		}
+
+		changeset := make(map[string]int64, len(calendar.RepairCost))
+		for currency, amount := range calendar.RepairCost {
+			changeset[currency] = -amount
+		}
+
+		// Count the streak as last claimed yesterday, so today's claim carries it on.
+		local := t.In(time.Local)
+		dailyReward.LastClaimUnix = time.Date(local.Year(), local.Month(), local.Day()-1, 12, 0, 0, 0, time.Local).Unix()
+		dailyReward.Repaired = true
+
+		write, err := dailyRewardWrite(userID, dailyReward, version)
+		if err != nil {
+			logger.Error("Marshal error: %v", err)
+			return "", errInternalError
+		}
+		var wallets []*runtime.WalletUpdate
+		if len(changeset) > 0 {
+			wallets = append(wallets, &runtime.WalletUpdate{
+				UserID:    userID,
+				Changeset: changeset,
+				Metadata:  map[string]interface{}{"streak_repair": dailyReward.Streak},
+			})
+		}
+		// The wallet update rejects the whole repair if the user can't afford it.
+		if _, _, err := nk.MultiUpdate(ctx, nil, []*runtime.StorageWrite{write}, nil, wallets, true); err != nil {
+			var negative *runtime.WalletNegativeError
+			if errors.As(err, &negative) {
+				return "", errInsufficientFunds
+			}
+			logger.Error("MultiUpdate error: %v", err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(calendar.response(dailyReward, t))
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
 }
@@ -0,0 +1,91 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const moduleNameConnectFour = "connect-four"
+
+var _ GameRules = &connectFourRules{}
+
+// Players take turns dropping their mark into a column, where it falls to the lowest empty row. The first to get
+// WinLength in a row wins. Any position in a column can be sent as a move to drop into that column.
+type connectFourRules struct{}
+
+func (r *connectFourRules) Name() string {
+	return moduleNameConnectFour
+}
+
+func (r *connectFourRules) DefaultDimensions() BoardDimensions {
+	return BoardDimensions{
+		Width:     7,
+		Height:    6,
+		WinLength: 4,
+	}
+}
+
+func (r *connectFourRules) ValidateDimensions(d BoardDimensions) error {
+	return d.validate(4, maxBoardSize, 3)
+}
+
+func (r *connectFourRules) NewBoard(d BoardDimensions) []api.Mark {
+	return make([]api.Mark, d.Size())
+}
+
+func (r *connectFourRules) LegalMoves(d BoardDimensions, board []api.Mark, mark api.Mark) []int {
+	moves := make([]int, 0, d.Width)
+	for column := 0; column < d.Width; column++ {
+		if position := r.landingPosition(d, board, column); position >= 0 {
+			moves = append(moves, position)
+		}
+	}
+	return moves
+}
+
+func (r *connectFourRules) ApplyMove(d BoardDimensions, board []api.Mark, mark api.Mark, position int) (int, error) {
+	if position < 0 || position >= len(board) {
+		return -1, errIllegalMove
+	}
+	position = r.landingPosition(d, board, position%d.Width)
+	if position < 0 {
+		// The column is full.
+		return -1, errIllegalMove
+	}
+	board[position] = mark
+	return position, nil
+}
+
+func (r *connectFourRules) NextTurn(d BoardDimensions, board []api.Mark, mark api.Mark) api.Mark {
+	return opponentMark(mark)
+}
+
+func (r *connectFourRules) Result(d BoardDimensions, board []api.Mark, lastPosition int) (bool, api.Mark, []int32) {
+	if winningLine := d.WinningLine(board, lastPosition); winningLine != nil {
+		return true, board[lastPosition], winningLine
+	}
+	return boardFull(board), api.Mark_MARK_UNSPECIFIED, nil
+}
+
+// Find the lowest empty position in the column, or -1 if it's full.
+func (r *connectFourRules) landingPosition(d BoardDimensions, board []api.Mark, column int) int {
+	for y := d.Height - 1; y >= 0; y-- {
+		if position := y*d.Width + column; board[position] == api.Mark_MARK_UNSPECIFIED {
+			return position
+		}
+	}
+	return -1
+}
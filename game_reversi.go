@@ -0,0 +1,150 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const moduleNameReversi = "reversi"
+
+// The directions discs can be flipped in.
+var reversiDirections = [][2]int{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+var _ GameRules = &reversiRules{}
+
+// Players take turns placing a mark that outflanks a line of opponent marks, which are then flipped. A player with no
+// legal move passes. The round ends when neither player can move, and whoever has the most marks wins.
+type reversiRules struct{}
+
+func (r *reversiRules) Name() string {
+	return moduleNameReversi
+}
+
+func (r *reversiRules) DefaultDimensions() BoardDimensions {
+	return BoardDimensions{
+		Width:  8,
+		Height: 8,
+	}
+}
+
+func (r *reversiRules) ValidateDimensions(d BoardDimensions) error {
+	if d.Width < 4 || d.Width > maxBoardSize || d.Height < 4 || d.Height > maxBoardSize || d.Width%2 != 0 || d.Height%2 != 0 {
+		return fmt.Errorf("board width and height must be even, and between 4 and %d", maxBoardSize)
+	}
+	if d.WinLength != 0 {
+		return fmt.Errorf("win length is not used")
+	}
+	return nil
+}
+
+func (r *reversiRules) NewBoard(d BoardDimensions) []api.Mark {
+	board := make([]api.Mark, d.Size())
+	x, y := d.Width/2-1, d.Height/2-1
+	board[y*d.Width+x] = api.Mark_MARK_O
+	board[y*d.Width+x+1] = api.Mark_MARK_X
+	board[(y+1)*d.Width+x] = api.Mark_MARK_X
+	board[(y+1)*d.Width+x+1] = api.Mark_MARK_O
+	return board
+}
+
+func (r *reversiRules) LegalMoves(d BoardDimensions, board []api.Mark, mark api.Mark) []int {
+	moves := make([]int, 0)
+	for _, position := range emptyPositions(board) {
+		if len(r.flips(d, board, mark, position)) > 0 {
+			moves = append(moves, position)
+		}
+	}
+	return moves
+}
+
+func (r *reversiRules) ApplyMove(d BoardDimensions, board []api.Mark, mark api.Mark, position int) (int, error) {
+	if position < 0 || position >= len(board) || board[position] != api.Mark_MARK_UNSPECIFIED {
+		return -1, errIllegalMove
+	}
+	flips := r.flips(d, board, mark, position)
+	if len(flips) == 0 {
+		// A move must outflank at least one opponent mark.
+		return -1, errIllegalMove
+	}
+	board[position] = mark
+	for _, flip := range flips {
+		board[flip] = mark
+	}
+	return position, nil
+}
+
+func (r *reversiRules) NextTurn(d BoardDimensions, board []api.Mark, mark api.Mark) api.Mark {
+	if opponent := opponentMark(mark); r.canMove(d, board, opponent) {
+		return opponent
+	}
+	// The opponent has no legal moves and must pass.
+	return mark
+}
+
+func (r *reversiRules) Result(d BoardDimensions, board []api.Mark, lastPosition int) (bool, api.Mark, []int32) {
+	if r.canMove(d, board, api.Mark_MARK_X) || r.canMove(d, board, api.Mark_MARK_O) {
+		return false, api.Mark_MARK_UNSPECIFIED, nil
+	}
+
+	counts := make(map[api.Mark]int, 2)
+	for _, mark := range board {
+		counts[mark]++
+	}
+	switch {
+	case counts[api.Mark_MARK_X] > counts[api.Mark_MARK_O]:
+		return true, api.Mark_MARK_X, nil
+	case counts[api.Mark_MARK_O] > counts[api.Mark_MARK_X]:
+		return true, api.Mark_MARK_O, nil
+	default:
+		return true, api.Mark_MARK_UNSPECIFIED, nil
+	}
+}
+
+func (r *reversiRules) canMove(d BoardDimensions, board []api.Mark, mark api.Mark) bool {
+	for _, position := range emptyPositions(board) {
+		if len(r.flips(d, board, mark, position)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Find the opponent marks that would be flipped by the mark playing at the given position.
+func (r *reversiRules) flips(d BoardDimensions, board []api.Mark, mark api.Mark, position int) []int {
+	opponent := opponentMark(mark)
+	x, y := position%d.Width, position/d.Width
+
+	var flips []int
+	for _, dir := range reversiDirections {
+		var line []int
+		nx, ny := x+dir[0], y+dir[1]
+		for d.contains(nx, ny) && board[ny*d.Width+nx] == opponent {
+			line = append(line, ny*d.Width+nx)
+			nx, ny = nx+dir[0], ny+dir[1]
+		}
+		// The line only flips if it's closed off by one of the player's own marks.
+		if len(line) > 0 && d.contains(nx, ny) && board[ny*d.Width+nx] == mark {
+			flips = append(flips, line...)
+		}
+	}
+	return flips
+}
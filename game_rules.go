@@ -0,0 +1,93 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+var errIllegalMove = errors.New("illegal move")
+
+// GameRules implements a two player, turn based board game that MatchHandler can host. The match handler takes care
+// of presences, turn deadlines, labels and starting new rounds, and defers to the rules for everything else.
+type GameRules interface {
+	// Name of the match module the game is registered under.
+	Name() string
+	// DefaultDimensions returns the board used when a match doesn't ask for a specific one.
+	DefaultDimensions() BoardDimensions
+	// ValidateDimensions checks the game can be played on a board with the given dimensions.
+	ValidateDimensions(d BoardDimensions) error
+	// NewBoard returns the board at the start of a round.
+	NewBoard(d BoardDimensions) []api.Mark
+	// LegalMoves returns the positions the mark can currently play.
+	LegalMoves(d BoardDimensions, board []api.Mark, mark api.Mark) []int
+	// ApplyMove plays the mark at the given position, updating the board in place. Returns the position the mark
+	// ended up in, which for some games differs from the one requested.
+	ApplyMove(d BoardDimensions, board []api.Mark, mark api.Mark, position int) (int, error)
+	// NextTurn returns whose turn it is after the mark has moved.
+	NextTurn(d BoardDimensions, board []api.Mark, mark api.Mark) api.Mark
+	// Result checks if the round is over after a move at the given position. If it is, also returns the winner and
+	// the positions that won the round, if any.
+	Result(d BoardDimensions, board []api.Mark, lastPosition int) (done bool, winner api.Mark, winnerPositions []int32)
+}
+
+// All the games MatchHandler can host, keyed by match module name.
+var games = map[string]GameRules{
+	moduleName:            &ticTacToeRules{},
+	moduleNameConnectFour: &connectFourRules{},
+	moduleNameReversi:     &reversiRules{},
+}
+
+// Find the rules for the given game, falling back to tic-tac-toe if none is given.
+func resolveGame(name string) (GameRules, bool) {
+	if name == "" {
+		name = moduleName
+	}
+	rules, ok := games[name]
+	return rules, ok
+}
+
+// Read the board dimensions from match parameters, using the game's defaults for anything not set.
+func boardDimensionsFromParams(rules GameRules, params map[string]interface{}) (BoardDimensions, error) {
+	defaults := rules.DefaultDimensions()
+	d := BoardDimensions{
+		Width:     intParam(params, "width", defaults.Width),
+		Height:    intParam(params, "height", defaults.Height),
+		WinLength: intParam(params, "win_length", defaults.WinLength),
+	}
+	return d, rules.ValidateDimensions(d)
+}
+
+func opponentMark(mark api.Mark) api.Mark {
+	switch mark {
+	case api.Mark_MARK_X:
+		return api.Mark_MARK_O
+	case api.Mark_MARK_O:
+		return api.Mark_MARK_X
+	default:
+		return api.Mark_MARK_UNSPECIFIED
+	}
+}
+
+func boardFull(board []api.Mark) bool {
+	for _, mark := range board {
+		if mark == api.Mark_MARK_UNSPECIFIED {
+			return false
+		}
+	}
+	return true
+}
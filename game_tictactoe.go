@@ -0,0 +1,74 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+// Classic 3x3 tic-tac-toe.
+var ticTacToeDimensions = BoardDimensions{
+	Width:     3,
+	Height:    3,
+	WinLength: 3,
+}
+
+var _ GameRules = &ticTacToeRules{}
+
+// Players take turns placing their mark in any empty position, the first to get WinLength in a row wins. Larger
+// boards make for Gomoku-style games.
+type ticTacToeRules struct{}
+
+func (r *ticTacToeRules) Name() string {
+	return moduleName
+}
+
+func (r *ticTacToeRules) DefaultDimensions() BoardDimensions {
+	return ticTacToeDimensions
+}
+
+func (r *ticTacToeRules) ValidateDimensions(d BoardDimensions) error {
+	return d.validate(3, maxBoardSize, 3)
+}
+
+func (r *ticTacToeRules) NewBoard(d BoardDimensions) []api.Mark {
+	return make([]api.Mark, d.Size())
+}
+
+func (r *ticTacToeRules) LegalMoves(d BoardDimensions, board []api.Mark, mark api.Mark) []int {
+	return emptyPositions(board)
+}
+
+func (r *ticTacToeRules) ApplyMove(d BoardDimensions, board []api.Mark, mark api.Mark, position int) (int, error) {
+	if position < 0 || position >= len(board) || board[position] != api.Mark_MARK_UNSPECIFIED {
+		// Position outside the board, or one that has already been played.
+		return -1, errIllegalMove
+	}
+	board[position] = mark
+	return position, nil
+}
+
+func (r *ticTacToeRules) NextTurn(d BoardDimensions, board []api.Mark, mark api.Mark) api.Mark {
+	return opponentMark(mark)
+}
+
+func (r *ticTacToeRules) Result(d BoardDimensions, board []api.Mark, lastPosition int) (bool, api.Mark, []int32) {
+	// Check if game is over through a winning move.
+	if winningLine := d.WinningLine(board, lastPosition); winningLine != nil {
+		return true, board[lastPosition], winningLine
+	}
+	// Check if game is over because no more moves are possible.
+	return boardFull(board), api.Mark_MARK_UNSPECIFIED, nil
+}
@@ -17,22 +17,70 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"google.golang.org/protobuf/encoding/protojson"
+	"strconv"
 	"time"
 )
 
 var (
-	errInternalError  = runtime.NewError("internal server error", 13) // INTERNAL
-	errMarshal        = runtime.NewError("cannot marshal type", 13)   // INTERNAL
-	errNoInputAllowed = runtime.NewError("no input allowed", 3)       // INVALID_ARGUMENT
-	errNoUserIdFound  = runtime.NewError("no user ID in context", 3)  // INVALID_ARGUMENT
-	errUnmarshal      = runtime.NewError("cannot unmarshal type", 13) // INTERNAL
+	errAsyncGameChanged         = runtime.NewError("game changed, fetch it and try again", 10) // ABORTED
+	errAsyncGameNotFound        = runtime.NewError("game not found", 5)                        // NOT_FOUND
+	errAsyncGameOver            = runtime.NewError("game is over", 9)                          // FAILED_PRECONDITION
+	errAsyncIllegalMove         = runtime.NewError("illegal move", 3)                          // INVALID_ARGUMENT
+	errChallengeNotFound        = runtime.NewError("challenge not found", 5)                   // NOT_FOUND
+	errInsufficientFunds        = runtime.NewError("insufficient funds", 9)                    // FAILED_PRECONDITION
+	errInternalError            = runtime.NewError("internal server error", 13)                // INTERNAL
+	errInvalidBestOf            = runtime.NewError("best of must be odd", 3)                   // INVALID_ARGUMENT
+	errInvalidCode              = runtime.NewError("invalid code", 3)                          // INVALID_ARGUMENT
+	errInvalidLimit             = runtime.NewError("limit must be between 1 and 100", 3)       // INVALID_ARGUMENT
+	errInvalidMatchmakerEntries = runtime.NewError("matchmaker must match exactly 2 users", 3) // INVALID_ARGUMENT
+	errInvalidReplayRequest     = runtime.NewError("match ID and round are required", 3)       // INVALID_ARGUMENT
+	errInvalidTimeControl       = runtime.NewError("invalid time bank or increment", 3)        // INVALID_ARGUMENT
+	errInvalidTurnHours         = runtime.NewError("turn hours must be between 1 and 168", 3)  // INVALID_ARGUMENT
+	errMarshal                  = runtime.NewError("cannot marshal type", 13)                  // INTERNAL
+	errMatchNotFound            = runtime.NewError("match not found", 5)                       // NOT_FOUND
+	errNoInputAllowed           = runtime.NewError("no input allowed", 3)                      // INVALID_ARGUMENT
+	errNoUserIdFound            = runtime.NewError("no user ID in context", 3)                 // INVALID_ARGUMENT
+	errNotFriends               = runtime.NewError("can only play with friends", 9)            // FAILED_PRECONDITION
+	errNotYourTurn              = runtime.NewError("not your turn", 9)                         // FAILED_PRECONDITION
+	errReplayNotFound           = runtime.NewError("replay not found", 5)                      // NOT_FOUND
+	errStreakNotRepairable      = runtime.NewError("streak cannot be repaired", 9)             // FAILED_PRECONDITION
+	errUnknownGame              = runtime.NewError("unknown game", 3)                          // INVALID_ARGUMENT
+	errUnmarshal                = runtime.NewError("cannot unmarshal type", 13)                // INTERNAL
 )
 
 const (
-	rpcIdRewards   = "rewards"
-	rpcIdFindMatch = "find_match"
+	// Default for how long a player waits alone in a match before the AI joins as their opponent.
+	aiBackfillDefaultSec = 20
+	// Default for how long the turn clock waits for a disconnected player to return before they forfeit.
+	reconnectGraceDefaultSec = 30
+)
+
+const (
+	rpcIdRewards            = "rewards"
+	rpcIdFindMatch          = "find_match"
+	rpcIdGetReplay          = "get_replay"
+	rpcIdGetStats           = "get_stats"
+	rpcIdListMatchHistory   = "list_match_history"
+	rpcIdCreatePrivateMatch = "create_private_match"
+	rpcIdJoinByCode         = "join_by_code"
+	rpcIdChallengeFriend    = "challenge_friend"
+	rpcIdAcceptChallenge    = "accept_challenge"
+	rpcIdDeclineChallenge   = "decline_challenge"
+	rpcIdCreateAsyncGame    = "create_async_game"
+	rpcIdGetAsyncGame       = "get_async_game"
+	rpcIdAsyncMove          = "async_move"
+	rpcIdListAsyncGames     = "list_async_games"
+	rpcIdGetSeason          = "get_season"
+	rpcIdGetSeasonStanding  = "get_season_standing"
+	rpcIdListSeasonAround   = "list_season_around"
+	rpcIdGetTournament      = "get_tournament_bracket"
+	rpcIdListAchievements   = "list_achievements"
+	rpcIdGetRewardCalendar  = "get_reward_calendar"
+	rpcIdRepairRewardStreak = "repair_reward_streak"
 )
 
 // noinspection GoUnusedExportedFunction
@@ -46,7 +94,32 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 		DiscardUnknown: false,
 	}
 
-	if err := initializer.RegisterRpc(rpcIdRewards, rpcRewards); err != nil {
+	achievements, err := loadAchievements(achievementsJSON)
+	if err != nil {
+		return fmt.Errorf("invalid achievements: %w", err)
+	}
+	progression, err := loadProgression(progressionJSON)
+	if err != nil {
+		return fmt.Errorf("invalid progression: %w", err)
+	}
+	calendar, err := loadRewardCalendar(rewardCalendarJSON)
+	if err != nil {
+		return fmt.Errorf("invalid reward calendar: %w", err)
+	}
+
+	if err := initializer.RegisterRpc(rpcIdRewards, rpcRewards(calendar, achievements)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetRewardCalendar, rpcGetRewardCalendar(marshaler, calendar)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdRepairRewardStreak, rpcRepairRewardStreak(marshaler, calendar)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListAchievements, rpcListAchievements(marshaler, achievements)); err != nil {
 		return err
 	}
 
@@ -54,20 +127,164 @@ func InitModule(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runti
 		return err
 	}
 
-	if err := initializer.RegisterMatch(moduleName, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
-		return &MatchHandler{
-			marshaler:        marshaler,
-			unmarshaler:      unmarshaler,
-			tfServingAddress: "http://tf:8501/v1/models/ttt:predict",
-		}, nil
-	}); err != nil {
+	if err := initializer.RegisterRpc(rpcIdGetReplay, rpcGetReplay(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetStats, rpcGetStats(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListMatchHistory, rpcListMatchHistory(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdCreatePrivateMatch, rpcCreatePrivateMatch(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdJoinByCode, rpcJoinByCode(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdChallengeFriend, rpcChallengeFriend(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdAcceptChallenge, rpcAcceptChallenge(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdDeclineChallenge, rpcDeclineChallenge(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdCreateAsyncGame, rpcCreateAsyncGame(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetAsyncGame, rpcGetAsyncGame(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdAsyncMove, rpcAsyncMove(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListAsyncGames, rpcListAsyncGames(marshaler, unmarshaler)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterMatchmakerMatched(matchmakerMatched); err != nil {
+		return err
+	}
+
+	aiPool := newAIWorkerPool(logger, nk, marshaler, aiWorkerCount, aiQueueSize, aiRequestTimeout)
+	aiPlayers := newAIPlayers(logger, nk, "http://tf:8501/v1/models/ttt:predict")
+	recordPool := newRecordWorkerPool(nk, recordWorkerCount, recordQueueSize, recordJobTimeout)
+
+	env, _ := ctx.Value(runtime.RUNTIME_CTX_ENV).(map[string]string)
+	aiBackfillSec, err := envSeconds(env, "ai_backfill_sec", aiBackfillDefaultSec)
+	if err != nil {
+		return err
+	}
+	reconnectGraceSec, err := envSeconds(env, "reconnect_grace_sec", reconnectGraceDefaultSec)
+	if err != nil {
+		return err
+	}
+
+	seasonID := seasonDefaultID
+	if value, ok := env["ranked_season_id"]; ok {
+		seasonID = value
+	}
+	seasonResetSchedule := seasonDefaultResetSchedule
+	if value, ok := env["ranked_season_reset"]; ok {
+		seasonResetSchedule = value
+	}
+	if err := createSeason(ctx, nk, seasonID, seasonResetSchedule); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterLeaderboardReset(seasonReset(seasonID)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdGetSeason, rpcGetSeason(marshaler, seasonID)); err != nil {
 		return err
 	}
 
-	if err := registerSessionEvents(db, nk, initializer); err != nil {
+	if err := initializer.RegisterRpc(rpcIdGetSeasonStanding, rpcGetSeasonStanding(marshaler, seasonID)); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterRpc(rpcIdListSeasonAround, rpcListSeasonAround(marshaler, unmarshaler, seasonID)); err != nil {
+		return err
+	}
+
+	tournamentID := tournamentDefaultID
+	if value, ok := env["tournament_id"]; ok {
+		tournamentID = value
+	}
+	tournamentSchedule := tournamentDefaultSchedule
+	if value, ok := env["tournament_schedule"]; ok {
+		tournamentSchedule = value
+	}
+	if err := createTournament(ctx, nk, tournamentID, tournamentSchedule); err != nil {
+		return err
+	}
+
+	if err := initializer.RegisterTournamentEnd(tournamentEnd(tournamentID, unmarshaler)); err != nil {
+		return err
+	}
+	scheduleTournamentDraws(logger, nk, marshaler, unmarshaler, tournamentID)
+
+	if err := initializer.RegisterRpc(rpcIdGetTournament, rpcGetTournamentBracket(marshaler, unmarshaler, tournamentID)); err != nil {
+		return err
+	}
+
+	for name, rules := range games {
+		if err := initializer.RegisterMatch(name, func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule) (runtime.Match, error) {
+			return &MatchHandler{
+				rules:           rules,
+				marshaler:       marshaler,
+				unmarshaler:     unmarshaler,
+				ai:              aiPool,
+				aiPlayers:       aiPlayers,
+				records:         recordPool,
+				aiBackfillTicks: int64(aiBackfillSec * tickRate),
+				graceTicks:      int64(reconnectGraceSec * tickRate),
+				seasonID:        seasonID,
+				achievements:    achievements,
+				progression:     progression,
+			}, nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := registerSessionEvents(db, nk, initializer, achievements); err != nil {
 		return err
 	}
 
 	logger.Info("Plugin loaded in '%d' msec.", time.Now().Sub(initStart).Milliseconds())
 	return nil
 }
+
+// Check if a storage write failed because the object changed since it was read, so it's worth reading it again and
+// retrying.
+func isVersionConflict(err error) bool {
+	return errors.Is(err, runtime.ErrStorageRejectedVersion)
+}
+
+// Read a number of seconds from the runtime environment, or use the default if it isn't set.
+func envSeconds(env map[string]string, key string, defaultSec int) (int, error) {
+	value, ok := env[key]
+	if !ok {
+		return defaultSec, nil
+	}
+	sec, err := strconv.Atoi(value)
+	if err != nil || sec < 0 {
+		return 0, fmt.Errorf("invalid runtime env %q: %q", key, value)
+	}
+	return sec, nil
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"math"
 	"math/rand"
 	"time"
 
@@ -35,64 +36,152 @@ const (
 
 	maxEmptySec = 30
 
+	maxSpectators = 100
+
+	maxDrawOffersPerRound = 3
+
+	// Rounds played after a level series before it's declared drawn.
+	maxSeriesExtraRounds = 3
+
+	// How long slots reserved for matched users are held for them.
+	reservationTimeoutSec = 15
+
 	delayBetweenGamesSec = 5
+	rematchTimeoutSec    = 15
 	turnTimeFastSec      = 10
 	turnTimeNormalSec    = 20
 )
 
-var winningPositions = [][]int32{
-	{0, 1, 2},
-	{3, 4, 5},
-	{6, 7, 8},
-	{0, 3, 6},
-	{1, 4, 7},
-	{2, 5, 8},
-	{0, 4, 8},
-	{2, 4, 6},
-}
-
 // Compile-time check to make sure all required functions are implemented.
 var _ runtime.Match = &MatchHandler{}
 
 type MatchLabel struct {
-	Open int `json:"open"`
-	Fast int `json:"fast"`
+	Game       string `json:"game"`
+	Open       int    `json:"open"`
+	Fast       int    `json:"fast"`
+	BestOf     int    `json:"best_of"`
+	Spectators int    `json:"spectators"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	WinLength  int    `json:"win_length"`
+	Region     string `json:"region"`
+	Private    int    `json:"private"`
+	// Rating of the player waiting for an opponent, and when the match started waiting in UNIX time.
+	Rating       int   `json:"rating"`
+	WaitingSince int64 `json:"waiting_since"`
+	// Seconds in each player's chess clock time bank and added per move, or 0 for fixed per-move deadlines.
+	TimeBank  int `json:"time_bank"`
+	Increment int `json:"increment"`
+	// The tournament the match is part of, if any.
+	Tournament string `json:"tournament"`
+	// Level and XP of each player, so lobbies can show them.
+	Players []*labelPlayer `json:"players"`
 }
 
 type MatchHandler struct {
-	marshaler        *protojson.MarshalOptions
-	unmarshaler      *protojson.UnmarshalOptions
-	tfServingAddress string
+	rules       GameRules
+	marshaler   *protojson.MarshalOptions
+	unmarshaler *protojson.UnmarshalOptions
+	ai          *aiWorkerPool
+	aiPlayers   map[string]AIPlayer
+	records     *recordWorkerPool
+	// Ticks a player waits alone before the AI joins as their opponent, or 0 to never bring it in.
+	aiBackfillTicks int64
+	// Ticks the turn clock waits for a disconnected player to return, or 0 to keep it running.
+	graceTicks int64
+	// Leaderboard ranked rounds count towards.
+	seasonID string
+	// Achievements players unlock by playing.
+	achievements achievementDefinitions
+	// XP rounds are worth, and the levels players reach with it.
+	progression *progressionConfig
 }
 
 type MatchState struct {
+	matchID    string
 	random     *rand.Rand
 	label      *MatchLabel
 	emptyTicks int
 	ai         bool
 	messages   chan runtime.MatchData
+	// Picks the moves for the AI, if it's playing.
+	aiPlayer AIPlayer
+	// Identifies the latest AI move request, results for any older request are discarded.
+	aiSeq int64
+	// True while waiting for the AI to produce a move.
+	aiPending bool
+	// Persistence work waiting for room in the record queue.
+	pendingRecords []func(ctx context.Context)
+	// Players' level and XP, sent back by the record workers when a player joins or a round's XP is saved.
+	labelPlayers chan []*labelPlayer
+	// Rating of the player waiting for an opponent, sent back by the record workers.
+	labelRatings chan labelRating
 
 	// Currently connected users, or reserved spaces.
 	presences map[string]runtime.Presence
 	// Number of users currently in the process of connecting to the match.
 	joinsInProgress int
+	// Ticks until reserved spaces nobody has claimed are released.
+	reservationRemainingTicks int64
+	// Ticks the only player in the match has been waiting for an opponent.
+	waitingTicks int64
+	// Users watching the match without playing, nil while they are still connecting.
+	spectators map[string]runtime.Presence
+	// Code users must pass in the join metadata, if the match is private.
+	code string
+	// Where the match sits in a tournament bracket, if it's part of one.
+	tournament *tournamentSlot
 
 	// True if there's a game currently in progress.
 	playing bool
+	// Size of the board and the number of marks in a row needed to win.
+	dimensions BoardDimensions
 	// Current state of the board.
 	board []api.Mark
 	// Mark assignments to player user IDs.
 	marks map[string]api.Mark
+	// Marks of players that left the current game and were replaced by the AI. They lose the game.
+	abandoned map[string]api.Mark
 	// Whose turn it currently is.
 	mark api.Mark
 	// Ticks until they must submit their move.
 	deadlineRemainingTicks int64
+	// Ticks left in each user ID's time bank when the player to move last changed, if the match uses a chess clock.
+	timeBankTicks map[string]int64
+	// Ticks left for the disconnected player to return while the turn clock is paused, or 0 if it isn't paused.
+	graceRemainingTicks int64
 	// The winner of the current game.
 	winner api.Mark
 	// The winner positions.
 	winnerPositions []int32
+	// How the current game ended, once it has.
+	doneReason api.DoneReason
+	// The user ID whose draw offer is waiting for a response, if any.
+	drawOfferedBy string
+	// Number of draws each user ID has offered in the current game.
+	drawOffers map[string]int
 	// Ticks until the next game starts, if applicable.
 	nextGameRemainingTicks int64
+	// True while the players vote on whether to play another round.
+	rematchPending bool
+	// User IDs that have voted for another round.
+	rematchVotes map[string]bool
+	// Ticks until the vote for another round closes.
+	rematchRemainingTicks int64
+	// Number of rounds started in the match so far.
+	round int32
+	// Record of the current round, saved once the round ends.
+	replay *api.Replay
+
+	// Number of rounds in the series, or 0 if rounds continue until the players leave.
+	bestOf int
+	// Rounds won by each user ID in the series so far.
+	seriesScore map[string]int32
+	// The user ID that won the series, once decided.
+	seriesWinner string
+
+	// True once the match is over, it closes once players have had time to see the result.
+	closing bool
 }
 
 func (ms *MatchState) ConnectedCount() int {
@@ -114,24 +203,81 @@ func (m *MatchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 
 	ai, _ := params["ai"].(bool)
 
+	difficulty := api.Difficulty_DIFFICULTY_UNSPECIFIED
+	if name, ok := params["difficulty"].(string); ok {
+		difficulty = api.Difficulty(api.Difficulty_value[name])
+	}
+
+	dimensions, err := boardDimensionsFromParams(m.rules, params)
+	if err != nil {
+		logger.WithField("error", err).Error("invalid match init board parameters")
+		return nil, 0, ""
+	}
+
+	bestOf := intParam(params, "best_of", 0)
+	if bestOf < 0 || (bestOf > 0 && bestOf%2 == 0) {
+		logger.Error("invalid match init parameter \"best_of\", must be an odd number")
+		return nil, 0, ""
+	}
+
+	timeBank := intParam(params, "time_bank_sec", 0)
+	increment := intParam(params, "increment_sec", 0)
+	if err := validateTimeControl(timeBank, increment); err != nil {
+		logger.WithField("error", err).Error("invalid match init time control parameters")
+		return nil, 0, ""
+	}
+
 	label := &MatchLabel{
-		Open: 1,
+		Game:         m.rules.Name(),
+		Open:         1,
+		BestOf:       bestOf,
+		Width:        dimensions.Width,
+		Height:       dimensions.Height,
+		WinLength:    dimensions.WinLength,
+		Rating:       intParam(params, "rating", ratingDefault),
+		WaitingSince: time.Now().Unix(),
+		TimeBank:     timeBank,
+		Increment:    increment,
+	}
+	label.Region, _ = params["region"].(string)
+	code, _ := params["code"].(string)
+	if code != "" {
+		label.Private = 1
 	}
 	if fast {
 		label.Fast = 1
 	}
-	labelJSON, err := json.Marshal(label)
-	if err != nil {
-		logger.WithField("error", err).Error("match init failed")
-		labelJSON = []byte("{}")
-	}
+
+	matchID, _ := ctx.Value(runtime.RUNTIME_CTX_MATCH_ID).(string)
 
 	state := &MatchState{
-		random:    rand.New(rand.NewSource(time.Now().UnixNano())),
-		label:     label,
-		ai:        ai,
-		presences: make(map[string]runtime.Presence, 2),
-		messages:  make(chan runtime.MatchData, 1),
+		matchID:      matchID,
+		code:         code,
+		random:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		label:        label,
+		ai:           ai,
+		aiPlayer:     m.resolveAIPlayer(difficulty),
+		presences:    make(map[string]runtime.Presence, 2),
+		spectators:   make(map[string]runtime.Presence),
+		messages:     make(chan runtime.MatchData, aiResultBufferSize),
+		labelPlayers: make(chan []*labelPlayer, 4),
+		labelRatings: make(chan labelRating, 1),
+		dimensions:   dimensions,
+		bestOf:       bestOf,
+	}
+	if bestOf > 0 {
+		state.seriesScore = make(map[string]int32, 2)
+	}
+	if tournamentID, ok := params["tournament_id"].(string); ok {
+		bracketKey, _ := params["bracket_key"].(string)
+		state.tournament = &tournamentSlot{
+			matchID:      matchID,
+			tournamentID: tournamentID,
+			bracketKey:   bracketKey,
+			round:        intParam(params, "bracket_round", 0),
+			pairing:      intParam(params, "bracket_pairing", 0),
+		}
+		label.Tournament = tournamentID
 	}
 
 	// Automatically add AI player
@@ -139,6 +285,23 @@ func (m *MatchHandler) MatchInit(ctx context.Context, logger runtime.Logger, db
 		state.presences[aiUserId] = aiPresenceObj
 	}
 
+	// Hold spaces for users the matchmaker has already paired into this match.
+	if reserved, ok := params["reserved"].([]string); ok && len(reserved) > 0 {
+		for _, userID := range reserved {
+			state.presences[userID] = nil
+		}
+		state.reservationRemainingTicks = int64(intParam(params, "reservation_sec", reservationTimeoutSec) * tickRate)
+		if len(state.presences) >= 2 {
+			label.Open = 0
+		}
+	}
+
+	labelJSON, err := json.Marshal(label)
+	if err != nil {
+		logger.WithField("error", err).Error("match init failed")
+		labelJSON = []byte("{}")
+	}
+
 	return state, tickRate, string(labelJSON)
 }
 
@@ -148,7 +311,7 @@ func (m *MatchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Logg
 	// Check if it's a user attempting to rejoin after a disconnect.
 	if presence, ok := s.presences[presence.GetUserId()]; ok {
 		if presence == nil {
-			// User rejoining after a disconnect.
+			// User rejoining after a disconnect, or claiming a space reserved for them.
 			s.joinsInProgress++
 			return s, true, ""
 		} else {
@@ -157,6 +320,28 @@ func (m *MatchHandler) MatchJoinAttempt(ctx context.Context, logger runtime.Logg
 		}
 	}
 
+	// Private matches can only be joined with their code.
+	if s.code != "" && normalizePrivateMatchCode(metadata["code"]) != s.code {
+		return s, false, "invalid code"
+	}
+
+	// Spectators don't take up a player slot.
+	if metadata["role"] == "spectator" {
+		if _, ok := s.spectators[presence.GetUserId()]; ok {
+			return s, false, "already joined"
+		}
+		if len(s.spectators) >= maxSpectators {
+			return s, false, "too many spectators"
+		}
+		s.spectators[presence.GetUserId()] = nil
+		return s, true, ""
+	}
+
+	// Spectators can't also take a player slot from another session.
+	if _, ok := s.spectators[presence.GetUserId()]; ok {
+		return s, false, "already joined"
+	}
+
 	// Check if match is full.
 	if len(s.presences)+s.joinsInProgress >= 2 {
 		return s, false, "match full"
@@ -172,22 +357,35 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 	t := time.Now().UTC()
 
 	for _, presence := range presences {
-		s.emptyTicks = 0
-		s.presences[presence.GetUserId()] = presence
-		s.joinsInProgress--
+		_, spectator := s.spectators[presence.GetUserId()]
+		if spectator {
+			s.spectators[presence.GetUserId()] = presence
+			s.label.Spectators = len(s.spectators)
+		} else {
+			s.emptyTicks = 0
+			s.presences[presence.GetUserId()] = presence
+			s.joinsInProgress--
+			m.loadLabelPlayer(logger, nk, s, presence.GetUserId())
+		}
 
 		// Check if we must send a message to this user to update them on the current game state.
 		var opCode api.OpCode
 		var msg proto.Message
 		if s.playing {
-			// There's a game still currently in progress, the player is re-joining after a disconnect. Give them a state update.
+			// There's a game still currently in progress, the player is re-joining after a disconnect or a spectator
+			// has started watching. Give them a state update.
 			opCode = api.OpCode_OPCODE_UPDATE
 			msg = &api.Update{
-				Board:    s.board,
-				Mark:     s.mark,
-				Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+				Board:     s.board,
+				Mark:      s.mark,
+				Deadline:  t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+				Width:     int32(s.dimensions.Width),
+				Height:    int32(s.dimensions.Height),
+				WinLength: int32(s.dimensions.WinLength),
+				Marks:     s.marks,
+				TimeBanks: s.timeBanks(),
 			}
-		} else if s.board != nil && s.marks != nil && s.marks[presence.GetUserId()] > api.Mark_MARK_UNSPECIFIED {
+		} else if s.board != nil && s.marks != nil && (spectator || s.marks[presence.GetUserId()] > api.Mark_MARK_UNSPECIFIED) {
 			// There's no game in progress but we still have a completed game that the user was part of.
 			// They likely disconnected before the game ended, and have since forfeited because they took too long to return.
 			opCode = api.OpCode_OPCODE_DONE
@@ -196,6 +394,9 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 				Winner:          s.winner,
 				WinnerPositions: s.winnerPositions,
 				NextGameStart:   t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
+				SeriesScore:     s.seriesScore,
+				Round:           s.round,
+				Reason:          s.doneReason,
 			}
 		}
 
@@ -213,14 +414,8 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 	// Check if match was open to new players, but should now be closed.
 	if len(s.presences) >= 2 && s.label.Open != 0 {
 		s.label.Open = 0
-		if labelJSON, err := json.Marshal(s.label); err != nil {
-			logger.Error("error encoding label: %v", err)
-		} else {
-			if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
-				logger.Error("error updating label: %v", err)
-			}
-		}
 	}
+	updateLabel(logger, dispatcher, s.label)
 
 	return s
 }
@@ -228,9 +423,19 @@ func (m *MatchHandler) MatchJoin(ctx context.Context, logger runtime.Logger, db
 func (m *MatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, presences []runtime.Presence) interface{} {
 	s := state.(*MatchState)
 
+	spectatorsLeft := false
 	for _, presence := range presences {
+		if _, ok := s.spectators[presence.GetUserId()]; ok {
+			delete(s.spectators, presence.GetUserId())
+			spectatorsLeft = true
+			continue
+		}
 		s.presences[presence.GetUserId()] = nil
 	}
+	if spectatorsLeft {
+		s.label.Spectators = len(s.spectators)
+		updateLabel(logger, dispatcher, s.label)
+	}
 
 	var humanPlayersRemaining []runtime.Presence
 	for userId, presence := range s.presences {
@@ -255,11 +460,17 @@ func (m *MatchHandler) MatchLeave(ctx context.Context, logger runtime.Logger, db
 func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, messages []runtime.MatchData) interface{} {
 	s := state.(*MatchState)
 
-	if s.ConnectedCount()+s.joinsInProgress == 0 {
+	m.flushRecords(s)
+	m.applyLabelPlayers(logger, dispatcher, s)
+	m.applyLabelRating(logger, dispatcher, s)
+
+	// Matches aren't idle while they're holding spaces for players on their way.
+	if s.ConnectedCount()+s.joinsInProgress == 0 && s.reservationRemainingTicks == 0 {
 		s.emptyTicks++
 		if s.emptyTicks >= maxEmptySec*tickRate {
 			// Match has been empty for too long, close it.
 			logger.Info("closing idle match")
+			m.closeMatch(logger, nk, s)
 			return nil
 		}
 	}
@@ -268,23 +479,103 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 
 	// If there's no game in progress check if we can (and should) start one!
 	if !s.playing {
+		// Once the match is over, close it when the next round would have started.
+		if s.closing {
+			if s.nextGameRemainingTicks > 0 {
+				s.nextGameRemainingTicks--
+				return s
+			}
+			logger.Info("closing match after it ended")
+			m.closeMatch(logger, nk, s)
+			return nil
+		}
+
 		// Between games any disconnected users are purged, there's no in-progress game for them to return to anyway.
-		for userID, presence := range s.presences {
-			if presence == nil {
-				delete(s.presences, userID)
+		// Spaces reserved for matched users are kept until they've had time to join.
+		if s.reservationRemainingTicks > 0 {
+			s.reservationRemainingTicks--
+		} else {
+			purged := false
+			for userID, presence := range s.presences {
+				if presence == nil {
+					delete(s.presences, userID)
+					s.removeLabelPlayer(userID)
+					purged = true
+				}
+			}
+			if purged {
+				updateLabel(logger, dispatcher, s.label)
+			}
+		}
+
+		// A series can't continue with a new opponent, if a player left the one still here wins it. Tournament players
+		// that don't turn up before their space is released lose the same way.
+		if s.bestOf > 0 && (len(s.marks) > 0 || (s.tournament != nil && s.reservationRemainingTicks == 0)) && len(s.presences) < 2 {
+			for userID := range s.presences {
+				s.seriesWinner = userID
+			}
+			if len(s.presences) == 0 {
+				// Both players have gone, so there's nobody left to show the result to. The series goes to whoever
+				// was ahead, if anyone.
+				logger.Info("series abandoned by both players")
+				s.seriesWinner, _ = s.seriesLeader()
+				s.nextGameRemainingTicks = 0
+			}
+			if slot := s.tournament; slot != nil {
+				winner := s.seriesWinner
+				var username string
+				if presence := s.presences[winner]; presence != nil {
+					username = presence.GetUsername()
+				}
+				m.submitRecord(s, func(ctx context.Context) {
+					m.reportTournamentResult(ctx, logger, nk, slot, winner, username)
+				})
+			}
+			m.endSeries(logger, dispatcher, s)
+			return s
+		}
+
+		// Outside of a series, another round only starts if the players agree to a rematch.
+		if s.rematchPending {
+			if !s.rematchPlayersPresent() {
+				// The opponent has left, the player still here can wait for a new one instead.
+				s.rematchPending = false
+				s.nextGameRemainingTicks = min(s.nextGameRemainingTicks, delayBetweenGamesSec*tickRate)
+			} else {
+				m.handleRematchVotes(logger, dispatcher, s, messages)
+				if s.closing {
+					return s
+				}
+				if !s.rematchAgreed() {
+					s.rematchRemainingTicks--
+					if s.rematchRemainingTicks <= 0 {
+						// Nobody declined, but not everyone agreed in time either.
+						m.declineRematch(logger, dispatcher, s, "")
+					}
+					return s
+				}
+				s.rematchPending = false
+				s.nextGameRemainingTicks = 0
 			}
 		}
 
 		// Check if we need to update the label so the match now advertises itself as open to join.
 		if len(s.presences) < 2 && s.label.Open != 1 {
 			s.label.Open = 1
-			if labelJSON, err := json.Marshal(s.label); err != nil {
-				logger.Error("error encoding label: %v", err)
-			} else {
-				if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
-					logger.Error("error updating label: %v", err)
-				}
+			s.label.WaitingSince = t.Unix()
+			m.loadLabelRating(logger, nk, s)
+			updateLabel(logger, dispatcher, s.label)
+		}
+
+		// Bring in the AI if a player has been waiting too long for a human opponent. Players in private matches are
+		// waiting for a friend, so keep waiting.
+		if m.aiBackfillTicks > 0 && s.code == "" && !s.ai && len(s.presences) == 1 && s.ConnectedCount() == 1 {
+			s.waitingTicks++
+			if s.waitingTicks >= m.aiBackfillTicks && m.addAI(logger, dispatcher, s) {
+				logger.Info("AI player backfilled match")
 			}
+		} else {
+			s.waitingTicks = 0
 		}
 
 		// Check if we have enough players to start a game.
@@ -300,12 +591,17 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 
 		// We can start a game! Set up the game state and assign the marks to each player.
 		s.playing = true
-		s.board = make([]api.Mark, 9)
+		s.board = m.rules.NewBoard(s.dimensions)
+		previousMarks := s.marks
 		s.marks = make(map[string]api.Mark, 2)
+		s.abandoned = nil
 		marks := []api.Mark{api.Mark_MARK_X, api.Mark_MARK_O}
 
 		for userID := range s.presences {
-			if s.ai {
+			if s.bestOf > 0 && previousMarks[userID] != api.Mark_MARK_UNSPECIFIED {
+				// Players take turns to go first in a series.
+				s.marks[userID] = opponentMark(previousMarks[userID])
+			} else if s.ai {
 				if userID == aiUserId {
 					s.marks[userID] = api.Mark_MARK_O
 				} else {
@@ -319,15 +615,32 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 		s.mark = api.Mark_MARK_X
 		s.winner = api.Mark_MARK_UNSPECIFIED
 		s.winnerPositions = nil
-		s.deadlineRemainingTicks = calculateDeadlineTicks(s.label)
+		s.doneReason = api.DoneReason_DONE_REASON_UNSPECIFIED
+		s.drawOfferedBy = ""
+		s.drawOffers = make(map[string]int, 2)
+		s.resetTimeBanks()
+		s.deadlineRemainingTicks = s.nextDeadlineTicks("")
 		s.nextGameRemainingTicks = 0
+		s.aiSeq++
+		s.aiPending = false
+		s.drainAIResults()
+		s.reservationRemainingTicks = 0
+		s.round++
+		s.replay = newReplay(s.matchID, s.round, m.rules, s, tick, t)
 
 		// Notify the players a new game has started.
 		buf, err := m.marshaler.Marshal(&api.Start{
-			Board:    s.board,
-			Marks:    s.marks,
-			Mark:     s.mark,
-			Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+			Board:       s.board,
+			Marks:       s.marks,
+			Mark:        s.mark,
+			Deadline:    t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+			Width:       int32(s.dimensions.Width),
+			Height:      int32(s.dimensions.Height),
+			WinLength:   int32(s.dimensions.WinLength),
+			SeriesScore: s.seriesScore,
+			BestOf:      int32(s.bestOf),
+			Round:       s.round,
+			TimeBanks:   s.timeBanks(),
 		})
 		if err != nil {
 			logger.Error("error encoding message: %v", err)
@@ -338,19 +651,39 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 	}
 
 	// Append AI moves, if any
-	select {
-	case msg := <-s.messages:
-		messages = append(messages, msg)
-	default:
+	for drained := false; !drained; {
+		select {
+		case msg := <-s.messages:
+			if aiMsg, ok := msg.(*aiMatchData); ok {
+				if aiMsg.seq != s.aiSeq {
+					// Result for a request made before the current game started.
+					break
+				}
+				s.aiPending = false
+				if aiMsg.err != nil {
+					logger.Error("error making AI turn: %v", aiMsg.err)
+					break
+				}
+			}
+			messages = append(messages, msg)
+		default:
+			drained = true
+		}
 	}
 
 	// There's a game in progress. Check for input, update match state, and send messages to clients.
 	for _, message := range messages {
+		if _, ok := s.spectators[message.GetUserId()]; ok {
+			// Spectators can only watch.
+			_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
+			continue
+		}
+
 		switch api.OpCode(message.GetOpCode()) {
 		case api.OpCode_OPCODE_MOVE:
 			mark := s.marks[message.GetUserId()]
-			if s.mark != mark {
-				// It is not this player's turn.
+			if !s.playing || s.mark != mark {
+				// The round has already ended this tick, or it is not this player's turn.
 				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
 				continue
 			}
@@ -362,109 +695,109 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
 				continue
 			}
-			if msg.Position < 0 || msg.Position > 8 || s.board[msg.Position] != api.Mark_MARK_UNSPECIFIED {
-				// Client sent a position outside the board, or one that has already been played.
+
+			// Update the game state.
+			position, err := m.rules.ApplyMove(s.dimensions, s.board, mark, int(msg.Position))
+			if err != nil {
+				// Client sent a move the game rules don't allow.
 				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
 				continue
 			}
+			s.mark = m.rules.NextTurn(s.dimensions, s.board, mark)
+			s.replay.Moves = append(s.replay.Moves, &api.ReplayMove{
+				UserId:    message.GetUserId(),
+				Mark:      mark,
+				Position:  int32(position),
+				Tick:      tick,
+				Timestamp: t.Unix(),
+			})
+			s.deadlineRemainingTicks = s.nextDeadlineTicks(message.GetUserId())
+			if s.drawOfferedBy != "" && s.drawOfferedBy != message.GetUserId() {
+				// Moving instead of answering a draw offer declines it.
+				s.drawOfferedBy = ""
+			}
 
-			// Update the game state.
-			s.board[msg.Position] = mark
-			switch mark {
-			case api.Mark_MARK_X:
-				s.mark = api.Mark_MARK_O
-			case api.Mark_MARK_O:
-				s.mark = api.Mark_MARK_X
-			}
-			s.deadlineRemainingTicks = calculateDeadlineTicks(s.label)
-
-			// Check if game is over through a winning move.
-		winCheck:
-			for _, winningPosition := range winningPositions {
-				for _, position := range winningPosition {
-					if s.board[position] != mark {
-						continue winCheck
-					}
+			// Check if game is over through a winning move, or because no more moves are possible.
+			if done, winner, winnerPositions := m.rules.Result(s.dimensions, s.board, position); done {
+				// Update state to reflect the result, and schedule the next game.
+				s.winner = winner
+				s.winnerPositions = winnerPositions
+				s.doneReason = api.DoneReason_DONE_REASON_WIN
+				if winner == api.Mark_MARK_UNSPECIFIED {
+					s.doneReason = api.DoneReason_DONE_REASON_TIE
 				}
+				m.endRound(ctx, logger, nk, dispatcher, tick, s, t)
+				continue
+			}
 
-				// Update state to reflect the winner, and schedule the next game.
-				s.winner = mark
-				s.winnerPositions = winningPosition
-				s.playing = false
-				s.deadlineRemainingTicks = 0
-				s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-			}
-			// Check if game is over because no more moves are possible.
-			tie := true
-			for _, mark := range s.board {
-				if mark == api.Mark_MARK_UNSPECIFIED {
-					tie = false
-					break
-				}
+			m.broadcastUpdate(logger, dispatcher, s, t)
+		case api.OpCode_OPCODE_RESIGN:
+			mark := s.marks[message.GetUserId()]
+			if !s.playing || mark == api.Mark_MARK_UNSPECIFIED {
+				// The round has already ended this tick, or the user isn't playing in it.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
+				continue
 			}
-			if tie {
-				// Update state to reflect the tie, and schedule the next game.
-				s.playing = false
-				s.deadlineRemainingTicks = 0
-				s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-			}
-
-			var opCode api.OpCode
-			var outgoingMsg proto.Message
-			if s.playing {
-				opCode = api.OpCode_OPCODE_UPDATE
-				outgoingMsg = &api.Update{
-					Board:    s.board,
-					Mark:     s.mark,
-					Deadline: t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
-				}
-			} else {
-				opCode = api.OpCode_OPCODE_DONE
-				outgoingMsg = &api.Done{
-					Board:           s.board,
-					Winner:          s.winner,
-					WinnerPositions: s.winnerPositions,
-					NextGameStart:   t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
-				}
+
+			s.winner = opponentMark(mark)
+			s.winnerPositions = nil
+			s.doneReason = api.DoneReason_DONE_REASON_RESIGN
+			m.endRound(ctx, logger, nk, dispatcher, tick, s, t)
+		case api.OpCode_OPCODE_DRAW_OFFER:
+			userID := message.GetUserId()
+			mark := s.marks[userID]
+			if !s.playing || s.mark != mark || s.drawOfferedBy != "" || s.drawOffers[userID] >= maxDrawOffersPerRound {
+				// Draws can only be offered on the player's own turn, one at a time, and only a few times per round.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
+				continue
 			}
 
-			buf, err := m.marshaler.Marshal(outgoingMsg)
-			if err != nil {
-				logger.Error("error encoding message: %v", err)
-			} else {
-				_ = dispatcher.BroadcastMessage(int64(opCode), buf, nil, nil, true)
+			s.drawOffers[userID]++
+			s.drawOfferedBy = userID
+			m.broadcastDrawResponse(logger, dispatcher, api.OpCode_OPCODE_DRAW_OFFER, &api.DrawResponse{UserId: userID})
+
+			// The AI plays on.
+			if s.userIDForMark(opponentMark(mark)) == aiUserId {
+				s.drawOfferedBy = ""
+				m.broadcastDrawResponse(logger, dispatcher, api.OpCode_OPCODE_DRAW_RESPONSE, &api.DrawResponse{UserId: aiUserId})
 			}
-		case api.OpCode_OPCODE_INVITE_AI:
-			if s.ai {
-				logger.Error("AI player is already playing")
+		case api.OpCode_OPCODE_DRAW_RESPONSE:
+			userID := message.GetUserId()
+			if !s.playing || s.drawOfferedBy == "" || s.drawOfferedBy == userID || s.marks[userID] == api.Mark_MARK_UNSPECIFIED {
+				// There's no draw offer for this player to respond to.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
 				continue
 			}
 
-			var activePlayers []runtime.Presence
-			for userId, presence := range s.presences {
-				if presence == nil {
-					delete(s.presences, userId)
-				} else if userId != aiUserId {
-					activePlayers = append(activePlayers, presence)
-				}
+			msg := &api.DrawResponse{}
+			if err := m.unmarshaler.Unmarshal(message.GetData(), msg); err != nil {
+				// Client sent bad data.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
+				continue
 			}
-			logger.Debug("active users: %d", len(activePlayers))
 
-			if len(activePlayers) != 1 {
-				logger.Error("one active player is required to enable AI mode")
+			s.drawOfferedBy = ""
+			m.broadcastDrawResponse(logger, dispatcher, api.OpCode_OPCODE_DRAW_RESPONSE, &api.DrawResponse{UserId: userID, Accept: msg.Accept})
+			if msg.Accept {
+				s.winner = api.Mark_MARK_UNSPECIFIED
+				s.winnerPositions = nil
+				s.doneReason = api.DoneReason_DONE_REASON_AGREED_DRAW
+				m.endRound(ctx, logger, nk, dispatcher, tick, s, t)
+			}
+		case api.OpCode_OPCODE_INVITE_AI:
+			if s.ai {
+				logger.Error("AI player is already playing")
 				continue
 			}
-
-			s.ai = true
-			s.presences[aiUserId] = aiPresenceObj
-
-			if s.marks[activePlayers[0].GetUserId()] == api.Mark_MARK_O {
-				s.marks[aiUserId] = api.Mark_MARK_X
-			} else {
-				s.marks[aiUserId] = api.Mark_MARK_O
+			if s.tournament != nil {
+				// Tournament players have to beat their opponent themselves.
+				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REJECTED), nil, []runtime.Presence{message}, nil, true)
+				continue
 			}
 
-			logger.Info("AI player joined match")
+			if m.addAI(logger, dispatcher, s) {
+				logger.Info("AI player joined match")
+			}
 
 		default:
 			// No other opcodes are expected from the client, so automatically treat it as an error.
@@ -473,37 +806,47 @@ func (m *MatchHandler) MatchLoop(ctx context.Context, logger runtime.Logger, db
 	}
 
 	// Keep track of the time remaining for the player to submit their move. Idle players forfeit.
-	if s.playing {
+	if s.playing && m.graceTicks > 0 {
+		if userID := s.userIDForMark(s.mark); userID != aiUserId && s.presences[userID] == nil {
+			// The player to move has disconnected, pause their clock for a while to give them a chance to return.
+			if s.graceRemainingTicks == 0 {
+				s.graceRemainingTicks = m.graceTicks
+				m.broadcastPause(logger, dispatcher, s, userID, t)
+			}
+			s.graceRemainingTicks--
+			if s.graceRemainingTicks <= 0 {
+				s.winner = opponentMark(s.mark)
+				s.winnerPositions = nil
+				s.doneReason = api.DoneReason_DONE_REASON_OPPONENT_LEFT
+				m.endRound(ctx, logger, nk, dispatcher, tick, s, t)
+			}
+		} else if s.graceRemainingTicks > 0 {
+			// The player is back, restart their clock where it left off.
+			s.graceRemainingTicks = 0
+			m.broadcastUpdate(logger, dispatcher, s, t)
+		}
+	}
+	if s.playing && s.graceRemainingTicks == 0 {
 		s.deadlineRemainingTicks--
 		if s.deadlineRemainingTicks <= 0 {
-			// The player has run out of time to submit their move.
-			s.playing = false
-			switch s.mark {
-			case api.Mark_MARK_X:
-				s.winner = api.Mark_MARK_O
-			case api.Mark_MARK_O:
-				s.winner = api.Mark_MARK_X
-			}
-			s.deadlineRemainingTicks = 0
-			s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
-
-			buf, err := m.marshaler.Marshal(&api.Done{
-				Board:         s.board,
-				Winner:        s.winner,
-				NextGameStart: t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
-			})
-			if err != nil {
-				logger.Error("error encoding message: %v", err)
-			} else {
-				_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_DONE), buf, nil, nil, true)
+			// The player has run out of time to submit their move, or their flag has fallen.
+			s.winner = opponentMark(s.mark)
+			s.winnerPositions = nil
+			s.doneReason = api.DoneReason_DONE_REASON_TIMEOUT
+			if userID := s.userIDForMark(s.mark); userID != aiUserId && s.presences[userID] == nil {
+				s.doneReason = api.DoneReason_DONE_REASON_OPPONENT_LEFT
 			}
+			m.endRound(ctx, logger, nk, dispatcher, tick, s, t)
 		}
 	}
 
-	// The next turn is AI's
-	if s.ai && s.mark == s.marks[aiUserId] {
-		if err := m.aiTurn(s); err != nil {
-			logger.Error("error making AI turn: %v", err)
+	// The next turn is AI's, ask for a move unless one is already on its way.
+	if s.playing && s.ai && s.mark == s.marks[aiUserId] && !s.aiPending {
+		s.aiSeq++
+		if m.ai.Submit(m.rules, s) {
+			s.aiPending = true
+		} else {
+			logger.Warn("AI queue full, retrying next tick")
 		}
 	}
 
@@ -515,9 +858,395 @@ func (m *MatchHandler) MatchSignal(ctx context.Context, logger runtime.Logger, d
 }
 
 func (m *MatchHandler) MatchTerminate(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, state interface{}, graceSeconds int) interface{} {
+	m.closeMatch(logger, nk, state.(*MatchState))
 	return state
 }
 
+// Clean up once the match stops: free its code if it's private, and hand over any persistence work still waiting.
+func (m *MatchHandler) closeMatch(logger runtime.Logger, nk runtime.NakamaModule, s *MatchState) {
+	if code, matchID := s.code, s.matchID; code != "" {
+		m.submitRecord(s, func(ctx context.Context) {
+			releasePrivateMatchCode(ctx, logger, nk, code, matchID)
+		})
+	}
+	m.closeRecords(s)
+}
+
+// Bring in the AI as the opponent of the only player left in the match, taking over the missing player's mark if a
+// round is in progress. Returns false if there isn't exactly one player to play against.
+func (m *MatchHandler) addAI(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState) bool {
+	var activePlayers []runtime.Presence
+	for userId, presence := range s.presences {
+		if presence == nil {
+			delete(s.presences, userId)
+		} else if userId != aiUserId {
+			activePlayers = append(activePlayers, presence)
+		}
+	}
+	logger.Debug("active users: %d", len(activePlayers))
+
+	if len(activePlayers) != 1 {
+		logger.Error("one active player is required to enable AI mode")
+		return false
+	}
+
+	s.ai = true
+	s.presences[aiUserId] = aiPresenceObj
+
+	if s.playing {
+		// The AI takes over from the player that left, who still loses the game.
+		for userID, mark := range s.marks {
+			if _, ok := s.presences[userID]; !ok {
+				if s.abandoned == nil {
+					s.abandoned = make(map[string]api.Mark, 1)
+				}
+				s.abandoned[userID] = mark
+				delete(s.marks, userID)
+				if bank, ok := s.timeBankTicks[userID]; ok {
+					delete(s.timeBankTicks, userID)
+					s.timeBankTicks[aiUserId] = bank
+				}
+			}
+		}
+		if s.marks[activePlayers[0].GetUserId()] == api.Mark_MARK_O {
+			s.marks[aiUserId] = api.Mark_MARK_X
+		} else {
+			s.marks[aiUserId] = api.Mark_MARK_O
+		}
+		s.replay.Marks[aiUserId] = s.marks[aiUserId]
+	}
+
+	if s.label.Open != 0 {
+		s.label.Open = 0
+		updateLabel(logger, dispatcher, s.label)
+	}
+
+	// Let the player know they're now playing against the AI.
+	_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_AI_JOINED), nil, activePlayers, nil, true)
+	return true
+}
+
+// Publish the current state of the label so the match can be found through match listings.
+func updateLabel(logger runtime.Logger, dispatcher runtime.MatchDispatcher, label *MatchLabel) {
+	if labelJSON, err := json.Marshal(label); err != nil {
+		logger.Error("error encoding label: %v", err)
+	} else {
+		if err := dispatcher.MatchLabelUpdate(string(labelJSON)); err != nil {
+			logger.Error("error updating label: %v", err)
+		}
+	}
+}
+
+// The rating of a player waiting for an opponent, as shown in the match label.
+type labelRating struct {
+	userID string
+	rating int
+}
+
+// Read the rating of the player still waiting in the match on the record workers, so the label can advertise it and
+// find_match can pair them with a similar opponent.
+func (m *MatchHandler) loadLabelRating(logger runtime.Logger, nk runtime.NakamaModule, s *MatchState) {
+	key := ratingKey(m.rules.Name(), s.label.Fast == 1)
+	labelRatings := s.labelRatings
+	for userID := range s.presences {
+		if userID == aiUserId {
+			continue
+		}
+		m.submitRecord(s, func(ctx context.Context) {
+			ratings, _, err := readRatings(ctx, nk, key, userID)
+			if err != nil {
+				logger.WithField("error", err).Error("error reading rating")
+				return
+			}
+			select {
+			case labelRatings <- labelRating{userID: userID, rating: int(math.Round(ratings[0].Rating))}:
+			default:
+				logger.Debug("dropping label rating for match that is no longer reading")
+			}
+		})
+	}
+}
+
+// Advertise the waiting player's rating once the record workers have read it, as long as they're still waiting.
+func (m *MatchHandler) applyLabelRating(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState) {
+	select {
+	case r := <-s.labelRatings:
+		if _, ok := s.presences[r.userID]; !ok || s.label.Open != 1 {
+			return
+		}
+		s.label.Rating = r.rating
+		updateLabel(logger, dispatcher, s.label)
+	default:
+	}
+}
+
+// Wrap up a round once its result is known: notify the players, keep score if it's part of a series, and schedule
+// the next round.
+func (m *MatchHandler) endRound(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, dispatcher runtime.MatchDispatcher, tick int64, s *MatchState, t time.Time) {
+	s.playing = false
+	s.deadlineRemainingTicks = 0
+	s.graceRemainingTicks = 0
+	s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
+	if s.bestOf == 0 {
+		// Players have until the next round would have started to agree to a rematch.
+		s.rematchPending = true
+		s.rematchVotes = make(map[string]bool, 2)
+		s.rematchRemainingTicks = rematchTimeoutSec * tickRate
+		s.nextGameRemainingTicks = s.rematchRemainingTicks
+	}
+
+	seriesOver := false
+	if s.bestOf > 0 {
+		if winnerID := s.userIDForMark(s.winner); winnerID != "" {
+			s.seriesScore[winnerID]++
+		}
+		seriesOver = s.decideSeries()
+	}
+
+	// Saving the round takes several round trips to the database, so it's done off the match loop.
+	record := m.newRoundRecord(s, tick, t)
+	if seriesOver {
+		record.tournament = s.tournament
+		record.seriesWinner = s.seriesWinner
+	}
+	labelPlayers := s.labelPlayers
+	m.submitRecord(s, func(ctx context.Context) {
+		m.recordRound(ctx, logger, nk, record, labelPlayers)
+	})
+
+	buf, err := m.marshaler.Marshal(&api.Done{
+		Board:           s.board,
+		Winner:          s.winner,
+		WinnerPositions: s.winnerPositions,
+		NextGameStart:   t.Add(time.Duration(s.nextGameRemainingTicks/tickRate) * time.Second).Unix(),
+		SeriesScore:     s.seriesScore,
+		Round:           s.round,
+		Reason:          s.doneReason,
+	})
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_DONE), buf, nil, nil, true)
+	}
+
+	if seriesOver {
+		m.endSeries(logger, dispatcher, s)
+	}
+}
+
+// The user ID ahead in the series and their score, or no user ID if the score is level.
+func (ms *MatchState) seriesLeader() (string, int32) {
+	var leader string
+	var best, second int32 = -1, -1
+	for userID := range ms.marks {
+		switch score := ms.seriesScore[userID]; {
+		case score > best:
+			leader, best, second = userID, score, best
+		case score > second:
+			second = score
+		}
+	}
+	if best == second {
+		return "", best
+	}
+	return leader, best
+}
+
+// Check if the series is over after a round, and if so who won it. Drawn rounds don't count, so once every round has
+// been played the player ahead wins. A level series carries on until a round is won, and is drawn if it's still level
+// after a few more rounds.
+func (ms *MatchState) decideSeries() bool {
+	leader, score := ms.seriesLeader()
+	if leader != "" && (score > int32(ms.bestOf/2) || ms.round >= int32(ms.bestOf)) {
+		ms.seriesWinner = leader
+		return true
+	}
+	return ms.round >= int32(ms.bestOf+maxSeriesExtraRounds)
+}
+
+// Announce the final result of a series. The match closes once players have had time to see the result.
+func (m *MatchHandler) endSeries(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState) {
+	s.closing = true
+
+	buf, err := m.marshaler.Marshal(&api.SeriesDone{
+		SeriesScore: s.seriesScore,
+		Winner:      s.seriesWinner,
+		BestOf:      int32(s.bestOf),
+	})
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_SERIES_DONE), buf, nil, nil, true)
+	}
+}
+
+// Tell everyone in the match the turn clock is paused while the player to move reconnects.
+func (m *MatchHandler) broadcastPause(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, userID string, t time.Time) {
+	buf, err := m.marshaler.Marshal(&api.Pause{
+		UserId:   userID,
+		Deadline: t.Add(time.Duration(s.graceRemainingTicks/tickRate) * time.Second).Unix(),
+	})
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_PAUSED), buf, nil, nil, true)
+	}
+}
+
+// Send everyone in the match the current state of the round.
+func (m *MatchHandler) broadcastUpdate(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, t time.Time) {
+	buf, err := m.marshaler.Marshal(&api.Update{
+		Board:     s.board,
+		Mark:      s.mark,
+		Deadline:  t.Add(time.Duration(s.deadlineRemainingTicks/tickRate) * time.Second).Unix(),
+		Width:     int32(s.dimensions.Width),
+		Height:    int32(s.dimensions.Height),
+		WinLength: int32(s.dimensions.WinLength),
+		TimeBanks: s.timeBanks(),
+	})
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_UPDATE), buf, nil, nil, true)
+	}
+}
+
+// Relay a draw offer, or the response to one, to everyone in the match.
+func (m *MatchHandler) broadcastDrawResponse(logger runtime.Logger, dispatcher runtime.MatchDispatcher, opCode api.OpCode, msg *api.DrawResponse) {
+	buf, err := m.marshaler.Marshal(msg)
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(opCode), buf, nil, nil, true)
+	}
+}
+
+// Count votes for and against a rematch. Only the players from the last round can vote.
+func (m *MatchHandler) handleRematchVotes(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, messages []runtime.MatchData) {
+	for _, message := range messages {
+		userID := message.GetUserId()
+		if _, ok := s.marks[userID]; !ok {
+			continue
+		}
+
+		switch opCode := api.OpCode(message.GetOpCode()); opCode {
+		case api.OpCode_OPCODE_REMATCH_REQUEST, api.OpCode_OPCODE_REMATCH_ACCEPT:
+			s.rematchVotes[userID] = true
+
+			// Let everyone know, so the opponent can accept the request.
+			buf, err := m.marshaler.Marshal(&api.Rematch{UserId: userID})
+			if err != nil {
+				logger.Error("error encoding message: %v", err)
+			} else {
+				_ = dispatcher.BroadcastMessage(int64(opCode), buf, nil, nil, true)
+			}
+		case api.OpCode_OPCODE_REMATCH_DECLINE:
+			m.declineRematch(logger, dispatcher, s, userID)
+			return
+		}
+	}
+}
+
+// End the match without another round, because the user declined or, if no user is given, the vote timed out.
+func (m *MatchHandler) declineRematch(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState, userID string) {
+	s.rematchPending = false
+	s.closing = true
+	s.nextGameRemainingTicks = delayBetweenGamesSec * tickRate
+
+	buf, err := m.marshaler.Marshal(&api.Rematch{UserId: userID})
+	if err != nil {
+		logger.Error("error encoding message: %v", err)
+	} else {
+		_ = dispatcher.BroadcastMessage(int64(api.OpCode_OPCODE_REMATCH_DECLINE), buf, nil, nil, true)
+	}
+}
+
+// Check if the players from the last round are all still in the match.
+// Discard AI results left over from the previous game, which is no longer read once it ends.
+func (ms *MatchState) drainAIResults() {
+	for {
+		select {
+		case <-ms.messages:
+		default:
+			return
+		}
+	}
+}
+
+func (ms *MatchState) rematchPlayersPresent() bool {
+	for userID := range ms.marks {
+		if ms.presences[userID] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if every human player from the last round wants a rematch. The AI always does.
+func (ms *MatchState) rematchAgreed() bool {
+	for userID := range ms.marks {
+		if userID != aiUserId && !ms.rematchVotes[userID] {
+			return false
+		}
+	}
+	return true
+}
+
+// Find the user playing the given mark in the current round, if any.
+func (ms *MatchState) userIDForMark(mark api.Mark) string {
+	return userIDForMark(ms.marks, mark)
+}
+
+func userIDForMark(marks map[string]api.Mark, mark api.Mark) string {
+	if mark == api.Mark_MARK_UNSPECIFIED {
+		return ""
+	}
+	for userID, m := range marks {
+		if m == mark {
+			return userID
+		}
+	}
+	return ""
+}
+
+// Show players' latest level and XP once the record workers have read or saved it.
+func (m *MatchHandler) applyLabelPlayers(logger runtime.Logger, dispatcher runtime.MatchDispatcher, s *MatchState) {
+	select {
+	case players := <-s.labelPlayers:
+		for _, player := range players {
+			s.setLabelPlayer(player)
+		}
+		updateLabel(logger, dispatcher, s.label)
+	default:
+	}
+}
+
+// Add or update a player in the label, as long as they're still in the match. XP only goes up, so an older read that
+// arrives late is ignored.
+func (ms *MatchState) setLabelPlayer(player *labelPlayer) {
+	if _, ok := ms.presences[player.UserID]; !ok {
+		return
+	}
+	for i, existing := range ms.label.Players {
+		if existing.UserID == player.UserID {
+			if player.XP >= existing.XP {
+				ms.label.Players[i] = player
+			}
+			return
+		}
+	}
+	ms.label.Players = append(ms.label.Players, player)
+}
+
+func (ms *MatchState) removeLabelPlayer(userID string) {
+	for i, player := range ms.label.Players {
+		if player.UserID == userID {
+			ms.label.Players = append(ms.label.Players[:i], ms.label.Players[i+1:]...)
+			return
+		}
+	}
+}
+
 func calculateDeadlineTicks(l *MatchLabel) int64 {
 	if l.Fast == 1 {
 		return turnTimeFastSec * tickRate
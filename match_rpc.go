@@ -17,18 +17,35 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"time"
 
+	nkapi "github.com/heroiclabs/nakama-common/api"
 	"github.com/heroiclabs/nakama-common/runtime"
 	"github.com/heroiclabs/nakama-project-template/api"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+const (
+	// Matches only accept opponents within this many rating points of the waiting player at first.
+	matchRatingBand = 100
+	// The band widens by this many points for every matchRatingWidenSec the match has been waiting...
+	matchRatingBandStep = 50
+	matchRatingWidenSec = 5
+	// ...up to this limit.
+	matchRatingMaxBand = 500
+
+	// How many open matches to consider when looking for the best one.
+	matchListLimit = 100
+)
+
 type nakamaRpcFunc func(context.Context, runtime.Logger, *sql.DB, runtime.NakamaModule, string) (string, error)
 
 func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
 	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
-		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
 		if !ok {
 			return "", errNoUserIdFound
 		}
@@ -38,11 +55,21 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 			return "", errUnmarshal
 		}
 
+		rules, dimensions, err := resolveMatchSettings(request.Game, request.Width, request.Height, request.WinLength, request.BestOf)
+		if err != nil {
+			return "", err
+		}
+		if err := validateTimeControl(int(request.TimeBankSec), int(request.IncrementSec)); err != nil {
+			return "", err
+		}
+
 		// If AI flag is set just create a brand-new match
 		if request.Ai {
 			matchID, err := nk.MatchCreate(
-				ctx, moduleName, map[string]interface{}{
-					"ai": true, "fast": request.Fast})
+				ctx, rules.Name(), map[string]interface{}{
+					"ai": true, "fast": request.Fast, "difficulty": request.Difficulty.String(), "best_of": int(request.BestOf),
+					"width": dimensions.Width, "height": dimensions.Height, "win_length": dimensions.WinLength,
+					"time_bank_sec": int(request.TimeBankSec), "increment_sec": int(request.IncrementSec)})
 			if err != nil {
 				logger.Error("error creating match: %v", err)
 				return "", errInternalError
@@ -60,35 +87,42 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 			return string(response), nil
 		}
 
+		ratings, _, err := readRatings(ctx, nk, ratingKey(rules.Name(), request.Fast), userID)
+		if err != nil {
+			logger.Error("error reading rating: %v", err)
+			return "", errInternalError
+		}
+		userRating := int(math.Round(ratings[0].Rating))
+
 		maxSize := 1
 		var fast int
 		if request.Fast {
 			fast = 1
 		}
-		query := fmt.Sprintf("+label.open:1 +label.fast:%d", fast)
+		query := fmt.Sprintf("+label.game:%q +label.open:1 +label.private:0 +label.fast:%d +label.best_of:%d +label.width:%d +label.height:%d +label.win_length:%d +label.time_bank:%d +label.increment:%d +label.rating:>=%d +label.rating:<=%d",
+			rules.Name(), fast, request.BestOf, dimensions.Width, dimensions.Height, dimensions.WinLength,
+			request.TimeBankSec, request.IncrementSec, userRating-matchRatingMaxBand, userRating+matchRatingMaxBand)
 
-		matchIDs := make([]string, 0, 10)
-		matches, err := nk.MatchList(ctx, 10, true, "", nil, &maxSize, query)
+		matches, err := nk.MatchList(ctx, matchListLimit, true, "", nil, &maxSize, query)
 		if err != nil {
 			logger.Error("error listing matches: %v", err)
 			return "", errInternalError
 		}
-		if len(matches) > 0 {
-			// There are one or more ongoing matches the user could join.
-			for _, match := range matches {
-				matchIDs = append(matchIDs, match.MatchId)
-			}
-		} else {
-			// No available matches found, create a new one.
-			matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{"fast": request.Fast})
+
+		matchID := bestMatch(logger, matches, userRating, time.Now())
+		if matchID == "" {
+			// No suitable matches found, create a new one.
+			matchID, err = nk.MatchCreate(ctx, rules.Name(), map[string]interface{}{
+				"fast": request.Fast, "best_of": int(request.BestOf), "rating": userRating,
+				"width": dimensions.Width, "height": dimensions.Height, "win_length": dimensions.WinLength,
+				"time_bank_sec": int(request.TimeBankSec), "increment_sec": int(request.IncrementSec)})
 			if err != nil {
 				logger.Error("error creating match: %v", err)
 				return "", errInternalError
 			}
-			matchIDs = append(matchIDs, matchID)
 		}
 
-		response, err := marshaler.Marshal(&api.RpcFindMatchResponse{MatchIds: matchIDs})
+		response, err := marshaler.Marshal(&api.RpcFindMatchResponse{MatchIds: []string{matchID}})
 		if err != nil {
 			logger.Error("error marshaling response payload: %v", err.Error())
 			return "", errMarshal
@@ -97,3 +131,62 @@ func rpcFindMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.Un
 		return string(response), nil
 	}
 }
+
+// Find the game a match request asks for, and check the board and series it asks for are valid. Zero values pick the
+// game's defaults.
+func resolveMatchSettings(game string, width, height, winLength, bestOf int32) (GameRules, BoardDimensions, error) {
+	rules, ok := resolveGame(game)
+	if !ok {
+		return nil, BoardDimensions{}, errUnknownGame
+	}
+
+	dimensions := rules.DefaultDimensions()
+	if width > 0 {
+		dimensions.Width = int(width)
+	}
+	if height > 0 {
+		dimensions.Height = int(height)
+	}
+	if winLength > 0 {
+		dimensions.WinLength = int(winLength)
+	}
+	if err := rules.ValidateDimensions(dimensions); err != nil {
+		return nil, BoardDimensions{}, runtime.NewError(err.Error(), 3) // INVALID_ARGUMENT
+	}
+	if bestOf < 0 || (bestOf > 0 && bestOf%2 == 0) {
+		return nil, BoardDimensions{}, errInvalidBestOf
+	}
+	return rules, dimensions, nil
+}
+
+// Pick the open match whose waiting player is closest in rating to the user, among those whose rating band covers
+// the user. The band widens the longer a match has been waiting, and ties go to the match that has waited longest.
+func bestMatch(logger runtime.Logger, matches []*nkapi.Match, userRating int, t time.Time) string {
+	bestID := ""
+	bestDiff := 0
+	var bestWaitingSince int64
+	for _, match := range matches {
+		label := &MatchLabel{}
+		if err := json.Unmarshal([]byte(match.GetLabel().GetValue()), label); err != nil {
+			logger.Error("error decoding label: %v", err)
+			continue
+		}
+
+		waitSec := max(t.Unix()-label.WaitingSince, 0)
+		band := min(matchRatingBand+int(waitSec/matchRatingWidenSec)*matchRatingBandStep, matchRatingMaxBand)
+		diff := userRating - label.Rating
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > band {
+			continue
+		}
+
+		if bestID == "" || diff < bestDiff || (diff == bestDiff && label.WaitingSince < bestWaitingSince) {
+			bestID = match.GetMatchId()
+			bestDiff = diff
+			bestWaitingSince = label.WaitingSince
+		}
+	}
+	return bestID
+}
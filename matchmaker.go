@@ -0,0 +1,77 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+)
+
+// Create an authoritative match for players paired by the Nakama matchmaker. Both player slots are reserved for the
+// matched users, so nobody else can join before they do.
+func matchmakerMatched(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, entries []runtime.MatchmakerEntry) (string, error) {
+	if len(entries) != 2 {
+		logger.Error("matchmaker matched %d users, expected 2", len(entries))
+		return "", errInvalidMatchmakerEntries
+	}
+
+	properties := entries[0].GetProperties()
+	game, _ := properties["game"].(string)
+	rules, ok := resolveGame(game)
+	if !ok {
+		return "", errUnknownGame
+	}
+	region, _ := properties["region"].(string)
+
+	userIDs := make([]string, 0, len(entries))
+	rating := 0.0
+	for _, entry := range entries {
+		userIDs = append(userIDs, entry.GetPresence().GetUserId())
+		if r, ok := entry.GetProperties()["rating"].(float64); ok {
+			rating += r
+		} else {
+			rating += ratingDefault
+		}
+	}
+
+	matchID, err := nk.MatchCreate(ctx, rules.Name(), map[string]interface{}{
+		"fast":     boolProperty(properties, "fast"),
+		"rating":   int(math.Round(rating / float64(len(entries)))),
+		"region":   region,
+		"reserved": userIDs,
+	})
+	if err != nil {
+		logger.Error("error creating match: %v", err)
+		return "", errInternalError
+	}
+
+	logger.Info("new matchmaker match created %s", matchID)
+	return matchID, nil
+}
+
+// Matchmaker properties are either strings or numbers, so flags may arrive as "true" or 1.
+func boolProperty(properties map[string]interface{}, key string) bool {
+	switch v := properties[key].(type) {
+	case string:
+		return v == "true" || v == "1"
+	case float64:
+		return v != 0
+	default:
+		return false
+	}
+}
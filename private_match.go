@@ -0,0 +1,263 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Codes map to match IDs in storage objects owned by the system user, keyed by code.
+	privateMatchCollection = "private_match"
+
+	// Codes leave out letters and digits that are easily confused, like O and 0.
+	privateMatchCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	privateMatchCodeLength   = 6
+	// How many codes to try before giving up, in case they're already taken.
+	privateMatchCodeAttempts = 5
+)
+
+// A private match code storage object.
+type privateMatchCode struct {
+	MatchID string `json:"match_id"` // Empty while the match is being created.
+}
+
+func newPrivateMatchCode() (string, error) {
+	code := make([]byte, privateMatchCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(privateMatchCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = privateMatchCodeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
+
+// Codes are case-insensitive, and users may paste them with surrounding whitespace.
+func normalizePrivateMatchCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Create a private match that isn't listed by find_match, and can only be joined with its code. The match is
+// created with the given parameters, plus the code.
+func createPrivateMatch(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, module string, params map[string]interface{}) (string, string, error) {
+	// Claim an unused code first, so the match never starts with a code that belongs to another match.
+	var code, version string
+	for attempt := 1; ; attempt++ {
+		var err error
+		code, err = newPrivateMatchCode()
+		if err != nil {
+			logger.Error("error generating code: %v", err)
+			return "", "", errInternalError
+		}
+		acks, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+			Collection:      privateMatchCollection,
+			Key:             code,
+			Value:           "{}",
+			Version:         "*", // Only if the code isn't taken.
+			PermissionRead:  0,   // No client read, codes are resolved through the RPC.
+			PermissionWrite: 0,   // No client write.
+		}})
+		if err == nil {
+			version = acks[0].GetVersion()
+			break
+		}
+		if attempt >= privateMatchCodeAttempts {
+			logger.Error("StorageWrite error: %v", err)
+			return "", "", errInternalError
+		}
+	}
+
+	params["code"] = code
+	matchID, err := nk.MatchCreate(ctx, module, params)
+	if err != nil {
+		logger.Error("error creating match: %v", err)
+		// Free the code, there's no match to join with it.
+		if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+			Collection: privateMatchCollection,
+			Key:        code,
+			Version:    version,
+		}}); err != nil {
+			logger.Error("StorageDelete error: %v", err)
+		}
+		return "", "", errInternalError
+	}
+
+	value, err := json.Marshal(&privateMatchCode{MatchID: matchID})
+	if err != nil {
+		logger.Error("Marshal error: %v", err)
+		return "", "", errInternalError
+	}
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      privateMatchCollection,
+		Key:             code,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  0, // No client read, codes are resolved through the RPC.
+		PermissionWrite: 0, // No client write.
+	}}); err != nil {
+		logger.Error("StorageWrite error: %v", err)
+		return "", "", errInternalError
+	}
+
+	logger.Info("new private match created %s", matchID)
+	return matchID, code, nil
+}
+
+// Free a match's code once the match is over, unless the code has already been freed and claimed by another match.
+func releasePrivateMatchCode(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, code, matchID string) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: privateMatchCollection,
+		Key:        code,
+	}})
+	if err != nil {
+		logger.Error("StorageRead error: %v", err)
+		return
+	}
+	if len(objects) == 0 {
+		return
+	}
+
+	privateMatch := &privateMatchCode{}
+	if err := json.Unmarshal([]byte(objects[0].GetValue()), privateMatch); err != nil {
+		logger.Error("Unmarshal error: %v", err)
+		return
+	}
+	if privateMatch.MatchID != matchID {
+		return
+	}
+	if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+		Collection: privateMatchCollection,
+		Key:        code,
+		Version:    objects[0].GetVersion(),
+	}}); err != nil {
+		logger.Error("StorageDelete error: %v", err)
+	}
+}
+
+// Create a private match for the user to share with a friend.
+func rpcCreatePrivateMatch(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcCreatePrivateMatchRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		rules, dimensions, err := resolveMatchSettings(request.Game, request.Width, request.Height, request.WinLength, request.BestOf)
+		if err != nil {
+			return "", err
+		}
+		if err := validateTimeControl(int(request.TimeBankSec), int(request.IncrementSec)); err != nil {
+			return "", err
+		}
+
+		matchID, code, err := createPrivateMatch(ctx, logger, nk, rules.Name(), map[string]interface{}{
+			"fast": request.Fast, "best_of": int(request.BestOf),
+			"width": dimensions.Width, "height": dimensions.Height, "win_length": dimensions.WinLength,
+			"time_bank_sec": int(request.TimeBankSec), "increment_sec": int(request.IncrementSec)})
+		if err != nil {
+			return "", err
+		}
+
+		response, err := marshaler.Marshal(&api.RpcCreatePrivateMatchResponse{MatchId: matchID, Code: code})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// Find the private match a code belongs to.
+func rpcJoinByCode(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		_, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcJoinByCodeRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+		code := normalizePrivateMatchCode(request.Code)
+		if code == "" {
+			return "", errInvalidCode
+		}
+
+		objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+			Collection: privateMatchCollection,
+			Key:        code,
+		}})
+		if err != nil {
+			logger.Error("StorageRead error: %v", err)
+			return "", errInternalError
+		}
+		if len(objects) == 0 {
+			return "", errMatchNotFound
+		}
+
+		privateMatch := &privateMatchCode{}
+		if err := json.Unmarshal([]byte(objects[0].GetValue()), privateMatch); err != nil {
+			logger.Error("Unmarshal error: %v", err)
+			return "", errUnmarshal
+		}
+		if privateMatch.MatchID == "" {
+			return "", errMatchNotFound
+		}
+
+		// The match may have stopped without freeing its code, for example if the server shut down.
+		match, err := nk.MatchGet(ctx, privateMatch.MatchID)
+		if err != nil {
+			logger.Error("error getting match: %v", err)
+			return "", errInternalError
+		}
+		if match == nil {
+			if err := nk.StorageDelete(ctx, []*runtime.StorageDelete{{
+				Collection: privateMatchCollection,
+				Key:        code,
+			}}); err != nil {
+				logger.Error("StorageDelete error: %v", err)
+			}
+			return "", errMatchNotFound
+		}
+
+		response, err := marshaler.Marshal(&api.RpcJoinByCodeResponse{MatchId: privateMatch.MatchID})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	notificationCodeLevelUp = 112
+
+	// XP is public, so other players can see each other's level.
+	progressionCollection = "progression"
+	progressionKey        = "xp"
+
+	progressionWriteAttempts = 3
+)
+
+// How much XP rounds are worth and the XP needed for each level, loaded when the module starts.
+//
+//go:embed progression.json
+var progressionJSON []byte
+
+type progressionConfig struct {
+	// XP for a normal speed round against a human, by result: "win", "draw" or "loss".
+	RoundXP map[string]int64 `json:"round_xp"`
+	// XP for fast rounds and rounds against the AI, as percentages of the XP above.
+	FastPercent int64 `json:"fast_percent"`
+	AIPercent   int64 `json:"ai_percent"`
+	// Levels from level 2 up, with the total XP needed to reach them.
+	Levels []*levelDefinition `json:"levels"`
+}
+
+type levelDefinition struct {
+	XP     int64            `json:"xp"`
+	Reward map[string]int64 `json:"reward"`
+}
+
+// A progression storage object for a user.
+type playerProgression struct {
+	XP         int64 `json:"xp"`
+	Level      int   `json:"level"`
+	UpdateUnix int64 `json:"update_unix"` // When XP was last granted in UNIX time.
+}
+
+// A player's level and XP, as shown in match labels.
+type labelPlayer struct {
+	UserID string `json:"user_id"`
+	Level  int    `json:"level"`
+	XP     int64  `json:"xp"`
+}
+
+func loadProgression(raw []byte) (*progressionConfig, error) {
+	config := &progressionConfig{}
+	if err := json.Unmarshal(raw, config); err != nil {
+		return nil, err
+	}
+
+	for _, result := range []string{"win", "draw", "loss"} {
+		if xp, ok := config.RoundXP[result]; !ok || xp < 0 {
+			return nil, fmt.Errorf("round XP for %q must be set and not negative", result)
+		}
+	}
+	if config.FastPercent < 0 || config.AIPercent < 0 {
+		return nil, errors.New("XP percentages must not be negative")
+	}
+	var previous int64
+	for i, level := range config.Levels {
+		if level.XP <= previous {
+			return nil, fmt.Errorf("level %d must need more XP than the level before it", i+2)
+		}
+		previous = level.XP
+	}
+	return config, nil
+}
+
+// XP for a player's result in a round.
+func (c *progressionConfig) roundXP(result api.RoundResult, fast, ai bool) int64 {
+	var xp int64
+	switch result {
+	case api.RoundResult_ROUND_RESULT_WIN:
+		xp = c.RoundXP["win"]
+	case api.RoundResult_ROUND_RESULT_DRAW:
+		xp = c.RoundXP["draw"]
+	default:
+		xp = c.RoundXP["loss"]
+	}
+	if fast {
+		xp = xp * c.FastPercent / 100
+	}
+	if ai {
+		xp = xp * c.AIPercent / 100
+	}
+	return xp
+}
+
+// The level a player with this much XP has reached, starting from 1.
+func (c *progressionConfig) level(xp int64) int {
+	level := 1
+	for _, definition := range c.Levels {
+		if xp < definition.XP {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+func readProgression(ctx context.Context, nk runtime.NakamaModule, userID string) (*playerProgression, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: progressionCollection,
+		Key:        progressionKey,
+		UserID:     userID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	progression := &playerProgression{Level: 1}
+	if len(objects) == 0 {
+		return progression, "*", nil
+	}
+	if err := json.Unmarshal([]byte(objects[0].GetValue()), progression); err != nil {
+		return nil, "", err
+	}
+	return progression, objects[0].GetVersion(), nil
+}
+
+// Add XP for a user. Rewards for any levels gained are granted in the same write as the XP, and the user is notified
+// of the new level.
+func (c *progressionConfig) grantXP(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, userID string, xp int64, t time.Time) (*playerProgression, error) {
+	for attempt := 1; ; attempt++ {
+		progression, version, err := readProgression(ctx, nk, userID)
+		if err != nil {
+			return nil, err
+		}
+		previousLevel := progression.Level
+		progression.XP += xp
+		progression.Level = c.level(progression.XP)
+		progression.UpdateUnix = t.Unix()
+
+		value, err := json.Marshal(progression)
+		if err != nil {
+			return nil, err
+		}
+		writes := []*runtime.StorageWrite{{
+			Collection:      progressionCollection,
+			Key:             progressionKey,
+			UserID:          userID,
+			Value:           string(value),
+			Version:         version,
+			PermissionRead:  2, // Public read, so players can see each other's level.
+			PermissionWrite: 0, // No client write.
+		}}
+		rewards := make(map[string]int64)
+		for level := previousLevel + 1; level <= progression.Level; level++ {
+			for currency, amount := range c.Levels[level-2].Reward {
+				rewards[currency] += amount
+			}
+		}
+		var wallets []*runtime.WalletUpdate
+		if len(rewards) > 0 {
+			wallets = append(wallets, &runtime.WalletUpdate{
+				UserID:    userID,
+				Changeset: rewards,
+				Metadata:  map[string]interface{}{"level": progression.Level},
+			})
+		}
+
+		if _, _, err := nk.MultiUpdate(ctx, nil, writes, nil, wallets, true); err != nil {
+			if attempt >= progressionWriteAttempts || !isVersionConflict(err) {
+				return nil, err
+			}
+			continue
+		}
+
+		if progression.Level > previousLevel {
+			err := nk.NotificationsSend(ctx, []*runtime.NotificationSend{{
+				UserID:  userID,
+				Subject: fmt.Sprintf("You've reached level %d!", progression.Level),
+				Content: map[string]interface{}{
+					"level":  progression.Level,
+					"reward": rewards,
+				},
+				Code:       notificationCodeLevelUp,
+				Persistent: true,
+			}})
+			if err != nil {
+				logger.Error("NotificationsSend error: %v", err)
+			}
+		}
+		return progression, nil
+	}
+}
+
+// Grant XP to every human player for the round that just ended, and return their new totals to show in the label.
+func (m *MatchHandler) recordRoundXP(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord) []*labelPlayer {
+	if m.progression == nil {
+		return nil
+	}
+	players := make([]*labelPlayer, 0, len(r.marks))
+	for userID, mark := range r.marks {
+		if userID == aiUserId {
+			continue
+		}
+
+		ai := r.userIDForMark(opponentMark(mark)) == aiUserId
+		xp := m.progression.roundXP(roundResult(r.winner, mark), r.fast, ai)
+		progression, err := m.progression.grantXP(ctx, logger, nk, userID, xp, r.endTime)
+		if err != nil {
+			logger.WithField("user_id", userID).Error("error granting XP: %v", err)
+			continue
+		}
+		players = append(players, &labelPlayer{UserID: userID, Level: progression.Level, XP: progression.XP})
+	}
+	return players
+}
+
+// Read a joining player's level and XP on the record workers, and send it back to the match to show in its label.
+func (m *MatchHandler) loadLabelPlayer(logger runtime.Logger, nk runtime.NakamaModule, s *MatchState, userID string) {
+	labelPlayers := s.labelPlayers
+	m.submitRecord(s, func(ctx context.Context) {
+		progression, _, err := readProgression(ctx, nk, userID)
+		if err != nil {
+			logger.WithField("user_id", userID).Error("error reading progression: %v", err)
+			return
+		}
+		sendLabelPlayers(logger, labelPlayers, []*labelPlayer{{UserID: userID, Level: progression.Level, XP: progression.XP}})
+	})
+}
+
+// Send players' level and XP back to the match, unless it has stopped reading them.
+func sendLabelPlayers(logger runtime.Logger, labelPlayers chan<- []*labelPlayer, players []*labelPlayer) {
+	select {
+	case labelPlayers <- players:
+	default:
+		logger.Debug("dropping label update for match that is no longer reading")
+	}
+}
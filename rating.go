@@ -0,0 +1,238 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	ratingCollection = "rating"
+
+	// Glicko-2 starting values for a new player.
+	ratingDefault           = 1500.0
+	ratingDeviationDefault  = 350.0
+	ratingVolatilityDefault = 0.06
+
+	// Constrains how quickly volatility can change.
+	ratingTau = 0.5
+	// Conversion factor between the Glicko and Glicko-2 scales.
+	ratingScale = 173.7178
+	// Convergence tolerance when solving for the new volatility.
+	ratingEpsilon = 0.000001
+
+	// How many times to retry a rating update that lost a race with a concurrent write.
+	ratingWriteAttempts = 3
+)
+
+// A player's Glicko-2 rating for one game and speed.
+type rating struct {
+	Rating     float64 `json:"rating"`
+	Deviation  float64 `json:"deviation"`
+	Volatility float64 `json:"volatility"`
+	Games      int     `json:"games"`
+	UpdateUnix int64   `json:"update_unix"` // The last time the rating changed in UNIX time.
+}
+
+func newRating() *rating {
+	return &rating{
+		Rating:     ratingDefault,
+		Deviation:  ratingDeviationDefault,
+		Volatility: ratingVolatilityDefault,
+	}
+}
+
+// Ratings are kept separately for each game, and for fast and normal speed.
+func ratingKey(game string, fast bool) string {
+	if fast {
+		return game + ".fast"
+	}
+	return game + ".normal"
+}
+
+// Update both players' ratings after a round between two human players. Rounds against the AI aren't rated, but a
+// player that left before the AI took over loses to the player still here.
+func (m *MatchHandler) updateRatings(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord) {
+	for userID, mark := range r.abandoned {
+		opponentID := r.userIDForMark(opponentMark(mark))
+		if opponentID == "" || opponentID == aiUserId {
+			continue
+		}
+		if err := writeRatings(ctx, nk, ratingKey(m.rules.Name(), r.fast), opponentID, userID, 1, r.endTime); err != nil {
+			logger.WithField("error", err).Error("error updating ratings")
+		}
+	}
+	if r.ai {
+		return
+	}
+	xUserID := r.userIDForMark(api.Mark_MARK_X)
+	oUserID := r.userIDForMark(api.Mark_MARK_O)
+	if xUserID == "" || oUserID == "" {
+		return
+	}
+
+	// The score for X: 1 for a win, 0.5 for a draw, 0 for a loss. Forfeits count the same as any other result.
+	xScore := 0.5
+	switch r.winner {
+	case api.Mark_MARK_X:
+		xScore = 1
+	case api.Mark_MARK_O:
+		xScore = 0
+	}
+
+	if err := writeRatings(ctx, nk, ratingKey(m.rules.Name(), r.fast), xUserID, oUserID, xScore, r.endTime); err != nil {
+		logger.WithField("error", err).Error("error updating ratings")
+	}
+}
+
+// Apply the result to both ratings and write them together. The write uses OCC on both objects, retrying if another
+// match updated either of them first.
+func writeRatings(ctx context.Context, nk runtime.NakamaModule, key, userID, opponentID string, score float64, t time.Time) error {
+	for attempt := 1; ; attempt++ {
+		ratings, versions, err := readRatings(ctx, nk, key, userID, opponentID)
+		if err != nil {
+			return err
+		}
+
+		r, opponent := ratings[0], ratings[1]
+		updated := r.update(opponent, score)
+		opponentUpdated := opponent.update(r, 1-score)
+
+		writes := make([]*runtime.StorageWrite, 0, 2)
+		for i, value := range []*rating{updated, opponentUpdated} {
+			value.UpdateUnix = t.Unix()
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			writes = append(writes, &runtime.StorageWrite{
+				Collection:      ratingCollection,
+				Key:             key,
+				UserID:          []string{userID, opponentID}[i],
+				Value:           string(raw),
+				Version:         versions[i],
+				PermissionRead:  2, // Public read, so opponents can see each other's rating.
+				PermissionWrite: 0, // No client write.
+			})
+		}
+
+		_, err = nk.StorageWrite(ctx, writes)
+		if err == nil || attempt >= ratingWriteAttempts || !isVersionConflict(err) {
+			return err
+		}
+	}
+}
+
+// Read the ratings for each user, along with the storage object versions to use for OCC. Users without a rating yet
+// get the default rating, and a version that only allows the object to be created.
+func readRatings(ctx context.Context, nk runtime.NakamaModule, key string, userIDs ...string) ([]*rating, []string, error) {
+	reads := make([]*runtime.StorageRead, 0, len(userIDs))
+	for _, userID := range userIDs {
+		reads = append(reads, &runtime.StorageRead{
+			Collection: ratingCollection,
+			Key:        key,
+			UserID:     userID,
+		})
+	}
+	objects, err := nk.StorageRead(ctx, reads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ratings := make([]*rating, len(userIDs))
+	versions := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		ratings[i] = newRating()
+		versions[i] = "*"
+		for _, object := range objects {
+			if object.GetUserId() != userID {
+				continue
+			}
+			if err := json.Unmarshal([]byte(object.GetValue()), ratings[i]); err != nil {
+				return nil, nil, err
+			}
+			versions[i] = object.GetVersion()
+		}
+	}
+	return ratings, versions, nil
+}
+
+// Return the rating after a single game against the opponent, with score 1 for a win, 0.5 for a draw and 0 for a
+// loss. Each game is treated as its own Glicko-2 rating period.
+func (r *rating) update(opponent *rating, score float64) *rating {
+	mu := (r.Rating - ratingDefault) / ratingScale
+	phi := r.Deviation / ratingScale
+	opponentMu := (opponent.Rating - ratingDefault) / ratingScale
+	opponentPhi := opponent.Deviation / ratingScale
+
+	g := 1 / math.Sqrt(1+3*opponentPhi*opponentPhi/(math.Pi*math.Pi))
+	e := 1 / (1 + math.Exp(-g*(mu-opponentMu)))
+	v := 1 / (g * g * e * (1 - e))
+	delta := v * g * (score - e)
+
+	sigma := r.newVolatility(phi, v, delta)
+
+	phiStar := math.Sqrt(phi*phi + sigma*sigma)
+	newPhi := 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+	newMu := mu + newPhi*newPhi*g*(score-e)
+
+	return &rating{
+		Rating:     newMu*ratingScale + ratingDefault,
+		Deviation:  math.Min(newPhi*ratingScale, ratingDeviationDefault),
+		Volatility: sigma,
+		Games:      r.Games + 1,
+	}
+}
+
+// Solve for the new volatility using the Illinois algorithm, as described in the Glicko-2 paper.
+func (r *rating) newVolatility(phi, v, delta float64) float64 {
+	a := math.Log(r.Volatility * r.Volatility)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-d)/(2*d*d) - (x-a)/(ratingTau*ratingTau)
+	}
+
+	x1 := a
+	var x2 float64
+	if delta*delta > phi*phi+v {
+		x2 = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*ratingTau) < 0 {
+			k++
+		}
+		x2 = a - k*ratingTau
+	}
+
+	f1, f2 := f(x1), f(x2)
+	for math.Abs(x2-x1) > ratingEpsilon {
+		c := x1 + (x1-x2)*f1/(f2-f1)
+		fc := f(c)
+		if fc*f2 <= 0 {
+			x1, f1 = x2, f2
+		} else {
+			f1 /= 2
+		}
+		x2, f2 = c, fc
+	}
+	return math.Exp(x1 / 2)
+}
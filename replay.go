@@ -0,0 +1,141 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Replays are owned by the system user, so only the server can read or write them.
+const replayCollection = "replay"
+
+func replayKey(matchID string, round int32) string {
+	return fmt.Sprintf("%s/%d", matchID, round)
+}
+
+// Start recording a new round.
+func newReplay(matchID string, round int32, rules GameRules, s *MatchState, tick int64, t time.Time) *api.Replay {
+	marks := make(map[string]api.Mark, len(s.marks))
+	for userID, mark := range s.marks {
+		marks[userID] = mark
+	}
+	return &api.Replay{
+		MatchId:   matchID,
+		Round:     round,
+		Game:      rules.Name(),
+		Width:     int32(s.dimensions.Width),
+		Height:    int32(s.dimensions.Height),
+		WinLength: int32(s.dimensions.WinLength),
+		Marks:     marks,
+		StartTick: tick,
+		StartTime: t.Unix(),
+	}
+}
+
+// Record the result of the round, and stop recording it.
+func (ms *MatchState) finishReplay(tick int64, t time.Time) *api.Replay {
+	replay := ms.replay
+	if replay == nil {
+		return nil
+	}
+	ms.replay = nil
+
+	replay.EndTick = tick
+	replay.EndTime = t.Unix()
+	replay.Board = append([]api.Mark(nil), ms.board...)
+	replay.Winner = ms.winner
+	replay.WinnerPositions = ms.winnerPositions
+	replay.Reason = ms.doneReason
+	return replay
+}
+
+// Persist the replay of a round that has ended.
+func (m *MatchHandler) saveReplay(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, replay *api.Replay) {
+	if replay == nil {
+		return
+	}
+
+	value, err := m.marshaler.Marshal(replay)
+	if err != nil {
+		logger.Error("error encoding replay: %v", err)
+		return
+	}
+
+	if _, err := nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      replayCollection,
+		Key:             replayKey(replay.MatchId, replay.Round),
+		PermissionRead:  0, // No client read, replays are fetched through the RPC.
+		PermissionWrite: 0, // No client write.
+		Value:           string(value),
+	}}); err != nil {
+		logger.Error("StorageWrite error: %v", err)
+	}
+}
+
+// Fetch the replay of a round the user played in.
+func rpcGetReplay(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcGetReplayRequest{}
+		if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+			return "", errUnmarshal
+		}
+		if request.MatchId == "" || request.Round < 1 {
+			return "", errInvalidReplayRequest
+		}
+
+		objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+			Collection: replayCollection,
+			Key:        replayKey(request.MatchId, request.Round),
+		}})
+		if err != nil {
+			logger.Error("StorageRead error: %v", err)
+			return "", errInternalError
+		}
+		if len(objects) == 0 {
+			return "", errReplayNotFound
+		}
+
+		replay := &api.Replay{}
+		if err := unmarshaler.Unmarshal([]byte(objects[0].GetValue()), replay); err != nil {
+			logger.Error("Unmarshal error: %v", err)
+			return "", errUnmarshal
+		}
+
+		// Only players in the round can see its replay.
+		if _, ok := replay.Marks[userID]; !ok {
+			return "", errReplayNotFound
+		}
+
+		response, err := marshaler.Marshal(replay)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+)
+
+const (
+	recordWorkerCount = 4
+	recordQueueSize   = 256
+	recordJobTimeout  = 30 * time.Second
+
+	metricRecordQueueDepth    = "record_queue_depth"
+	metricRecordQueueRejected = "record_queue_rejected"
+	metricRecordQueueWait     = "record_queue_wait"
+)
+
+// A round that has ended, copied out of the match state so it can be persisted while the match carries on.
+type roundRecord struct {
+	matchID         string
+	round           int32
+	fast            bool
+	private         bool
+	ai              bool
+	width           int
+	marks           map[string]api.Mark
+	abandoned       map[string]api.Mark
+	usernames       map[string]string
+	winner          api.Mark
+	winnerPositions []int32
+	doneReason      api.DoneReason
+	replay          *api.Replay
+	endTime         time.Time
+	// The tournament pairing the round decided, and who won it, if any.
+	tournament   *tournamentSlot
+	seriesWinner string
+}
+
+func (m *MatchHandler) newRoundRecord(s *MatchState, tick int64, t time.Time) *roundRecord {
+	r := &roundRecord{
+		matchID:         s.matchID,
+		round:           s.round,
+		fast:            s.label.Fast == 1,
+		private:         s.label.Private != 0,
+		ai:              s.ai,
+		width:           s.dimensions.Width,
+		marks:           make(map[string]api.Mark, len(s.marks)),
+		abandoned:       make(map[string]api.Mark, len(s.abandoned)),
+		usernames:       make(map[string]string, len(s.marks)),
+		winner:          s.winner,
+		winnerPositions: s.winnerPositions,
+		doneReason:      s.doneReason,
+		replay:          s.finishReplay(tick, t),
+		endTime:         t,
+	}
+	for userID, mark := range s.marks {
+		r.marks[userID] = mark
+		if presence := s.presences[userID]; presence != nil {
+			r.usernames[userID] = presence.GetUsername()
+		}
+	}
+	for userID, mark := range s.abandoned {
+		r.abandoned[userID] = mark
+	}
+	return r
+}
+
+func (r *roundRecord) userIDForMark(mark api.Mark) string {
+	return userIDForMark(r.marks, mark)
+}
+
+// Persist everything that changes when a round ends. Runs on the record workers, and sends players' new levels and XP
+// back to the match to show in its label.
+func (m *MatchHandler) recordRound(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord, labelPlayers chan<- []*labelPlayer) {
+	m.saveReplay(ctx, logger, nk, r.replay)
+	m.recordRoundStats(ctx, logger, nk, r)
+	m.updateRatings(ctx, logger, nk, r)
+	m.recordSeasonPoints(ctx, logger, nk, r)
+	m.recordRoundAchievements(ctx, logger, nk, r)
+	if players := m.recordRoundXP(ctx, logger, nk, r); len(players) > 0 {
+		sendLabelPlayers(logger, labelPlayers, players)
+	}
+	if r.tournament != nil {
+		m.reportTournamentResult(ctx, logger, nk, r.tournament, r.seriesWinner, r.usernames[r.seriesWinner])
+	}
+}
+
+// Queue persistence work for the record workers. Work the queue has no room for is kept in the match state, and
+// retried every tick in the order it was submitted.
+func (m *MatchHandler) submitRecord(s *MatchState, run func(ctx context.Context)) {
+	s.pendingRecords = append(s.pendingRecords, run)
+	m.flushRecords(s)
+}
+
+func (m *MatchHandler) flushRecords(s *MatchState) {
+	for len(s.pendingRecords) > 0 && m.records.Submit(s.pendingRecords[0]) {
+		s.pendingRecords[0] = nil
+		s.pendingRecords = s.pendingRecords[1:]
+	}
+}
+
+// Hand over any work still waiting when the match closes, as it won't get another tick to retry.
+func (m *MatchHandler) closeRecords(s *MatchState) {
+	m.flushRecords(s)
+	if len(s.pendingRecords) == 0 {
+		return
+	}
+	pending := s.pendingRecords
+	s.pendingRecords = nil
+	go func() {
+		for _, run := range pending {
+			m.records.jobs <- &recordJob{run: run, queuedAt: time.Now()}
+		}
+	}()
+}
+
+type recordJob struct {
+	run      func(ctx context.Context)
+	queuedAt time.Time
+}
+
+// A bounded pool of workers that persist round results outside the match loop, so the storage, leaderboard and wallet
+// writes that follow each round never stall a match tick.
+type recordWorkerPool struct {
+	nk      runtime.NakamaModule
+	timeout time.Duration
+	jobs    chan *recordJob
+}
+
+func newRecordWorkerPool(nk runtime.NakamaModule, workers, queueSize int, timeout time.Duration) *recordWorkerPool {
+	p := &recordWorkerPool{
+		nk:      nk,
+		timeout: timeout,
+		jobs:    make(chan *recordJob, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go p.run()
+	}
+	return p
+}
+
+// Submit queues work without blocking. Returns false if the queue is full.
+func (p *recordWorkerPool) Submit(run func(ctx context.Context)) bool {
+	select {
+	case p.jobs <- &recordJob{run: run, queuedAt: time.Now()}:
+		p.nk.MetricsGaugeSet(metricRecordQueueDepth, nil, float64(len(p.jobs)))
+		return true
+	default:
+		p.nk.MetricsCounterAdd(metricRecordQueueRejected, nil, 1)
+		return false
+	}
+}
+
+func (p *recordWorkerPool) run() {
+	for job := range p.jobs {
+		p.nk.MetricsGaugeSet(metricRecordQueueDepth, nil, float64(len(p.jobs)))
+		p.nk.MetricsTimerRecord(metricRecordQueueWait, nil, time.Since(job.queuedAt))
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		job.run(ctx)
+		cancel()
+	}
+}
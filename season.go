@@ -0,0 +1,340 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	nkapi "github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	notificationCodeSeasonReward = 108
+
+	// Defaults for the season leaderboard, which resets at midnight on the first of every month.
+	seasonDefaultID            = "ranked_season"
+	seasonDefaultResetSchedule = "0 0 1 * *"
+
+	// Points for each ranked round. Players are ranked by points, with round wins stored in the subscore.
+	seasonPointsWin  = 3
+	seasonPointsDraw = 1
+
+	// Badges are written by the server, and anyone can read them.
+	badgeCollection = "badge"
+
+	seasonResetPageSize      = 100
+	seasonAroundDefaultLimit = 20
+	seasonAroundMaxLimit     = 100
+)
+
+type seasonRewardTier struct {
+	Name    string
+	MaxRank int64
+	Coins   int64
+}
+
+// Season rewards from the highest tier down. Players get the first tier their final rank qualifies for.
+var seasonRewardTiers = []seasonRewardTier{
+	{Name: "gold", MaxRank: 10, Coins: 5000},
+	{Name: "silver", MaxRank: 100, Coins: 2000},
+	{Name: "bronze", MaxRank: 1000, Coins: 500},
+}
+
+// A badge storage object for a season reward tier a user earned.
+type seasonBadge struct {
+	Season  string `json:"season"`
+	EndUnix int64  `json:"end_unix"` // When the season ended in UNIX time.
+	Tier    string `json:"tier"`
+	Rank    int64  `json:"rank"`
+	Points  int64  `json:"points"`
+}
+
+func seasonRewardTierForRank(rank int64) *seasonRewardTier {
+	for i := range seasonRewardTiers {
+		if rank > 0 && rank <= seasonRewardTiers[i].MaxRank {
+			return &seasonRewardTiers[i]
+		}
+	}
+	return nil
+}
+
+func seasonRecord(record *nkapi.LeaderboardRecord) *api.SeasonRecord {
+	r := &api.SeasonRecord{
+		UserId:   record.GetOwnerId(),
+		Username: record.GetUsername().GetValue(),
+		Rank:     record.GetRank(),
+		Points:   record.GetScore(),
+		Wins:     record.GetSubscore(),
+	}
+	if tier := seasonRewardTierForRank(record.GetRank()); tier != nil {
+		r.Tier = tier.Name
+	}
+	return r
+}
+
+// Create the season leaderboard, if it doesn't exist yet.
+func createSeason(ctx context.Context, nk runtime.NakamaModule, seasonID, resetSchedule string) error {
+	return nk.LeaderboardCreate(ctx, seasonID, true, "desc", "incr", resetSchedule, map[string]interface{}{}, true)
+}
+
+// Add points for a ranked round to both players' season records. Only public rounds between two human players count,
+// and a player that left before the AI took over loses to the player still here.
+func (m *MatchHandler) recordSeasonPoints(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord) {
+	if m.seasonID == "" || r.private {
+		return
+	}
+	for userID, mark := range r.abandoned {
+		opponentID := r.userIDForMark(opponentMark(mark))
+		if opponentID == "" || opponentID == aiUserId {
+			continue
+		}
+		if _, err := nk.LeaderboardRecordWrite(ctx, m.seasonID, opponentID, r.usernames[opponentID], seasonPointsWin, 1, nil, nil); err != nil {
+			logger.WithField("user_id", opponentID).Error("error writing season record: %v", err)
+		}
+		if _, err := nk.LeaderboardRecordWrite(ctx, m.seasonID, userID, "", 0, 0, nil, nil); err != nil {
+			logger.WithField("user_id", userID).Error("error writing season record: %v", err)
+		}
+	}
+	if r.ai {
+		return
+	}
+	for userID, mark := range r.marks {
+		var points, wins int64
+		switch r.winner {
+		case api.Mark_MARK_UNSPECIFIED:
+			points = seasonPointsDraw
+		case mark:
+			points, wins = seasonPointsWin, 1
+		}
+
+		if _, err := nk.LeaderboardRecordWrite(ctx, m.seasonID, userID, r.usernames[userID], points, wins, nil, nil); err != nil {
+			logger.WithField("user_id", userID).Error("error writing season record: %v", err)
+		}
+	}
+}
+
+// Pay out rewards and badges to the best players when the season leaderboard resets.
+func seasonReset(seasonID string) func(context.Context, runtime.Logger, *sql.DB, runtime.NakamaModule, *nkapi.Leaderboard, int64) error {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, leaderboard *nkapi.Leaderboard, reset int64) error {
+		if leaderboard.GetId() != seasonID {
+			return nil
+		}
+
+		lowestRank := seasonRewardTiers[len(seasonRewardTiers)-1].MaxRank
+		cursor := ""
+		var grantErr error
+		for {
+			// List the records of the season that just ended, rather than the new one.
+			records, _, nextCursor, _, err := nk.LeaderboardRecordsList(ctx, seasonID, nil, seasonResetPageSize, cursor, reset)
+			if err != nil {
+				logger.Error("LeaderboardRecordsList error: %v", err)
+				return err
+			}
+			if err := grantSeasonRewards(ctx, logger, nk, seasonID, records, reset); err != nil && grantErr == nil {
+				grantErr = err
+			}
+			if nextCursor == "" || len(records) == 0 || records[len(records)-1].GetRank() >= lowestRank {
+				return grantErr
+			}
+			cursor = nextCursor
+		}
+	}
+}
+
+// Grant each player their tier's coins and badge, and let them know. Each player's badge is written in the same update
+// as their coins, and only if they don't have it yet, so nobody is paid twice for the same season.
+func grantSeasonRewards(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, seasonID string, records []*nkapi.LeaderboardRecord, endUnix int64) error {
+	var grantErr error
+	var notifications []*runtime.NotificationSend
+	for _, record := range records {
+		tier := seasonRewardTierForRank(record.GetRank())
+		if tier == nil {
+			continue
+		}
+
+		badge, err := json.Marshal(&seasonBadge{
+			Season:  seasonID,
+			EndUnix: endUnix,
+			Tier:    tier.Name,
+			Rank:    record.GetRank(),
+			Points:  record.GetScore(),
+		})
+		if err != nil {
+			return err
+		}
+
+		writes := []*runtime.StorageWrite{{
+			Collection:      badgeCollection,
+			Key:             fmt.Sprintf("%s.%d", seasonID, endUnix),
+			UserID:          record.GetOwnerId(),
+			Value:           string(badge),
+			Version:         "*", // Only if the player hasn't been rewarded yet.
+			PermissionRead:  2,
+			PermissionWrite: 0,
+		}}
+		wallets := []*runtime.WalletUpdate{{
+			UserID:    record.GetOwnerId(),
+			Changeset: map[string]int64{"coins": tier.Coins},
+			Metadata:  map[string]interface{}{"season": seasonID, "end_unix": endUnix, "rank": record.GetRank()},
+		}}
+		if _, _, err := nk.MultiUpdate(ctx, nil, writes, nil, wallets, true); err != nil {
+			if isVersionConflict(err) {
+				// Already rewarded, for example by an earlier attempt at this reset.
+				continue
+			}
+			logger.WithField("user_id", record.GetOwnerId()).Error("error granting season reward: %v", err)
+			if grantErr == nil {
+				grantErr = err
+			}
+			continue
+		}
+
+		notifications = append(notifications, &runtime.NotificationSend{
+			UserID:  record.GetOwnerId(),
+			Subject: "The ranked season is over!",
+			Content: map[string]interface{}{
+				"season": seasonID,
+				"tier":   tier.Name,
+				"rank":   record.GetRank(),
+				"coins":  tier.Coins,
+			},
+			Code:       notificationCodeSeasonReward,
+			Persistent: true,
+		})
+	}
+
+	if len(notifications) > 0 {
+		if err := nk.NotificationsSend(ctx, notifications); err != nil {
+			logger.Error("NotificationsSend error: %v", err)
+		}
+	}
+	return grantErr
+}
+
+func rpcGetSeason(marshaler *protojson.MarshalOptions, seasonID string) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
+		}
+
+		leaderboards, err := nk.LeaderboardsGetId(ctx, []string{seasonID})
+		if err != nil {
+			logger.Error("LeaderboardsGetId error: %v", err)
+			return "", errInternalError
+		}
+		if len(leaderboards) == 0 {
+			logger.Error("season leaderboard %q not found", seasonID)
+			return "", errInternalError
+		}
+
+		season := &api.Season{
+			Id:        seasonID,
+			StartTime: int64(leaderboards[0].GetPrevReset()),
+			EndTime:   int64(leaderboards[0].GetNextReset()),
+		}
+		for _, tier := range seasonRewardTiers {
+			season.RewardTiers = append(season.RewardTiers, &api.SeasonRewardTier{Name: tier.Name, MaxRank: tier.MaxRank, Coins: tier.Coins})
+		}
+
+		response, err := marshaler.Marshal(season)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+func rpcGetSeasonStanding(marshaler *protojson.MarshalOptions, seasonID string) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
+		}
+
+		_, ownerRecords, _, _, err := nk.LeaderboardRecordsList(ctx, seasonID, []string{userID}, 1, "", 0)
+		if err != nil {
+			logger.Error("LeaderboardRecordsList error: %v", err)
+			return "", errInternalError
+		}
+
+		response := &api.RpcGetSeasonStandingResponse{}
+		if len(ownerRecords) > 0 {
+			response.Record = seasonRecord(ownerRecords[0])
+		}
+
+		buf, err := marshaler.Marshal(response)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(buf), nil
+	}
+}
+
+func rpcListSeasonAround(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, seasonID string) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListSeasonAroundRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		limit := int(request.Limit)
+		if limit == 0 {
+			limit = seasonAroundDefaultLimit
+		}
+		if limit < 1 || limit > seasonAroundMaxLimit {
+			return "", errInvalidLimit
+		}
+
+		list, err := nk.LeaderboardRecordsHaystack(ctx, seasonID, userID, limit, "", 0)
+		if err != nil {
+			logger.Error("LeaderboardRecordsHaystack error: %v", err)
+			return "", errInternalError
+		}
+
+		records := make([]*api.SeasonRecord, 0, len(list.GetRecords()))
+		for _, record := range list.GetRecords() {
+			records = append(records, seasonRecord(record))
+		}
+
+		response, err := marshaler.Marshal(&api.RpcListSeasonAroundResponse{Records: records})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
@@ -28,19 +28,19 @@ const (
 	streamModeNotification = 0
 )
 
-func registerSessionEvents(db *sql.DB, nk runtime.NakamaModule, initializer runtime.Initializer) error {
-	if err := initializer.RegisterEventSessionStart(eventSessionStartFunc(nk)); err != nil {
+func registerSessionEvents(db *sql.DB, nk runtime.NakamaModule, initializer runtime.Initializer, achievements achievementDefinitions) error {
+	if err := initializer.RegisterEventSessionStart(eventSessionStartFunc(nk, achievements)); err != nil {
 		return err
 	}
-	if err := initializer.RegisterEventSessionEnd(eventSessionEndFunc(db)); err != nil {
+	if err := initializer.RegisterEventSessionEnd(eventSessionEndFunc(db, nk)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// Update a user's last online timestamp when they disconnect.
-func eventSessionEndFunc(db *sql.DB) func(context.Context, runtime.Logger, *api.Event) {
+// Update a user's last online timestamp when they disconnect, and cancel any challenges they're waiting on.
+func eventSessionEndFunc(db *sql.DB, nk runtime.NakamaModule) func(context.Context, runtime.Logger, *api.Event) {
 	return func(ctx context.Context, logger runtime.Logger, evt *api.Event) {
 		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
 		if !ok {
@@ -48,6 +48,16 @@ func eventSessionEndFunc(db *sql.DB) func(context.Context, runtime.Logger, *api.
 			return
 		}
 
+		// Challenges stay open while the user is still online on another session, for example the device that
+		// replaced this one.
+		sessionID, _ := ctx.Value(runtime.RUNTIME_CTX_SESSION_ID).(string)
+		presences, err := nk.StreamUserList(streamModeNotification, userID, "", "", true, true)
+		if err != nil {
+			logger.WithField("err", err).Error("nk.StreamUserList error.")
+		} else if !otherSessionOnline(presences, sessionID) {
+			cancelChallenges(ctx, logger, nk, userID)
+		}
+
 		// Restrict the time allowed with the DB operation so we can fail fast in a stampeding herd scenario.
 		ctx2, _ := context.WithTimeout(ctx, 1*time.Second)
 		query := `
@@ -60,7 +70,7 @@ SET
 WHERE
     id = $1;
 `
-		_, err := db.ExecContext(ctx2, query, userID)
+		_, err = db.ExecContext(ctx2, query, userID)
 		if err != nil && err != context.DeadlineExceeded {
 			logger.WithField("err", err).Error("db.ExecContext last online update error.")
 			return
@@ -68,8 +78,19 @@ WHERE
 	}
 }
 
-// Limit the number of concurrent realtime sessions active for a user to just one.
-func eventSessionStartFunc(nk runtime.NakamaModule) func(context.Context, runtime.Logger, *api.Event) {
+// Check if any of the user's presences belong to a session other than the given one.
+func otherSessionOnline(presences []runtime.Presence, sessionID string) bool {
+	for _, presence := range presences {
+		if presence.GetSessionId() != sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit the number of concurrent realtime sessions active for a user to just one, and count the session towards their
+// achievements.
+func eventSessionStartFunc(nk runtime.NakamaModule, achievements achievementDefinitions) func(context.Context, runtime.Logger, *api.Event) {
 	return func(ctx context.Context, logger runtime.Logger, evt *api.Event) {
 		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
 		if !ok {
@@ -83,6 +104,9 @@ func eventSessionStartFunc(nk runtime.NakamaModule) func(context.Context, runtim
 			return
 		}
 
+		// Count the session once any other devices have been kicked.
+		defer achievements.record(ctx, logger, nk, userID, achievementEventSessionStart)
+
 		// Fetch all live presences for this user on their private notification stream.
 		presences, err := nk.StreamUserList(streamModeNotification, userID, "", "", true, true)
 		if err != nil {
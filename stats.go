@@ -0,0 +1,293 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	statsCollection        = "stats"
+	statsKey               = "stats"
+	matchHistoryCollection = "match_history"
+
+	// How many times to retry a stats update that lost a race with a concurrent write.
+	statsWriteAttempts = 3
+
+	matchHistoryDefaultLimit = 20
+	matchHistoryMaxLimit     = 100
+)
+
+// History keys count down over time, so listing the collection returns the most recent rounds first.
+func matchHistoryKey(matchID string, round int32, t time.Time) string {
+	return fmt.Sprintf("%019d.%s.%d", math.MaxInt64-t.UnixNano(), matchID, round)
+}
+
+// Pick the stats record for the mode the round was played in, creating it if needed.
+func statsRecord(stats *api.Stats, fast, ai bool) *api.StatsRecord {
+	var record **api.StatsRecord
+	switch {
+	case fast && ai:
+		record = &stats.FastAi
+	case fast:
+		record = &stats.FastHuman
+	case ai:
+		record = &stats.NormalAi
+	default:
+		record = &stats.NormalHuman
+	}
+	if *record == nil {
+		*record = &api.StatsRecord{}
+	}
+	return *record
+}
+
+// The result of a round won by the given mark, for the player with the given mark.
+func roundResult(winner, mark api.Mark) api.RoundResult {
+	switch winner {
+	case api.Mark_MARK_UNSPECIFIED:
+		return api.RoundResult_ROUND_RESULT_DRAW
+	case mark:
+		return api.RoundResult_ROUND_RESULT_WIN
+	default:
+		return api.RoundResult_ROUND_RESULT_LOSS
+	}
+}
+
+// Update the stats and match history of every human player in the round that just ended.
+func (m *MatchHandler) recordRoundStats(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, r *roundRecord) {
+	for userID, mark := range r.marks {
+		if userID == aiUserId {
+			continue
+		}
+
+		opponentID := r.userIDForMark(opponentMark(mark))
+		entry := &api.MatchHistoryEntry{
+			MatchId:    r.matchID,
+			Round:      r.round,
+			Game:       m.rules.Name(),
+			OpponentId: opponentID,
+			Ai:         opponentID == aiUserId,
+			Fast:       r.fast,
+			Mark:       mark,
+			Forfeit:    r.doneReason == api.DoneReason_DONE_REASON_TIMEOUT || r.doneReason == api.DoneReason_DONE_REASON_OPPONENT_LEFT,
+			EndTime:    r.endTime.Unix(),
+			Reason:     r.doneReason,
+			Result:     roundResult(r.winner, mark),
+		}
+
+		if err := m.writeRoundStats(ctx, nk, userID, entry, r.endTime); err != nil {
+			logger.WithField("user_id", userID).Error("error recording stats: %v", err)
+		}
+	}
+
+	// Players the AI took over from forfeited the round to the player still here.
+	for userID, mark := range r.abandoned {
+		entry := &api.MatchHistoryEntry{
+			MatchId:    r.matchID,
+			Round:      r.round,
+			Game:       m.rules.Name(),
+			OpponentId: r.userIDForMark(opponentMark(mark)),
+			Fast:       r.fast,
+			Mark:       mark,
+			Forfeit:    true,
+			EndTime:    r.endTime.Unix(),
+			Reason:     api.DoneReason_DONE_REASON_OPPONENT_LEFT,
+			Result:     api.RoundResult_ROUND_RESULT_LOSS,
+		}
+
+		if err := m.writeRoundStats(ctx, nk, userID, entry, r.endTime); err != nil {
+			logger.WithField("user_id", userID).Error("error recording stats: %v", err)
+		}
+	}
+}
+
+// Write the round to the user's history and stats together. Stats are updated with OCC, retrying if another match
+// updated them first.
+func (m *MatchHandler) writeRoundStats(ctx context.Context, nk runtime.NakamaModule, userID string, entry *api.MatchHistoryEntry, t time.Time) error {
+	historyValue, err := m.marshaler.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		stats, version, err := readStats(ctx, nk, m.unmarshaler, userID)
+		if err != nil {
+			return err
+		}
+
+		record := statsRecord(stats, entry.Fast, entry.Ai)
+		switch entry.Result {
+		case api.RoundResult_ROUND_RESULT_WIN:
+			record.Wins++
+			if entry.Forfeit {
+				record.ForfeitWins++
+			}
+		case api.RoundResult_ROUND_RESULT_LOSS:
+			record.Losses++
+			if entry.Forfeit {
+				record.ForfeitLosses++
+			}
+		default:
+			record.Draws++
+		}
+
+		statsValue, err := m.marshaler.Marshal(stats)
+		if err != nil {
+			return err
+		}
+
+		if version == "" {
+			// Only create the object if no other match has created it in the meantime.
+			version = "*"
+		}
+
+		_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{
+			{
+				Collection:      statsCollection,
+				Key:             statsKey,
+				UserID:          userID,
+				Value:           string(statsValue),
+				Version:         version,
+				PermissionRead:  2, // Public read, so players can look up each other's stats.
+				PermissionWrite: 0, // No client write.
+			},
+			{
+				Collection:      matchHistoryCollection,
+				Key:             matchHistoryKey(entry.MatchId, entry.Round, t),
+				UserID:          userID,
+				Value:           string(historyValue),
+				PermissionRead:  1,
+				PermissionWrite: 0, // No client write.
+			},
+		})
+		if err == nil || attempt >= statsWriteAttempts || !isVersionConflict(err) {
+			return err
+		}
+	}
+}
+
+// Read the user's stats, along with the storage object version to use for OCC. The version is empty if the user
+// has no stats yet.
+func readStats(ctx context.Context, nk runtime.NakamaModule, unmarshaler *protojson.UnmarshalOptions, userID string) (*api.Stats, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: statsCollection,
+		Key:        statsKey,
+		UserID:     userID,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+
+	stats := &api.Stats{}
+	if len(objects) == 0 {
+		return stats, "", nil
+	}
+	if err := unmarshaler.Unmarshal([]byte(objects[0].GetValue()), stats); err != nil {
+		return nil, "", err
+	}
+	return stats, objects[0].GetVersion(), nil
+}
+
+// Fetch the stats of the calling user, or another user if one is given.
+func rpcGetStats(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcGetStatsRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+		if request.UserId != "" {
+			userID = request.UserId
+		}
+
+		stats, _, err := readStats(ctx, nk, unmarshaler, userID)
+		if err != nil {
+			logger.Error("error reading stats: %v", err)
+			return "", errInternalError
+		}
+
+		response, err := marshaler.Marshal(stats)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
+
+// List the rounds the calling user has played, most recent first.
+func rpcListMatchHistory(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		userID, ok := ctx.Value(runtime.RUNTIME_CTX_USER_ID).(string)
+		if !ok {
+			return "", errNoUserIdFound
+		}
+
+		request := &api.RpcListMatchHistoryRequest{}
+		if payload != "" {
+			if err := unmarshaler.Unmarshal([]byte(payload), request); err != nil {
+				return "", errUnmarshal
+			}
+		}
+
+		limit := int(request.Limit)
+		if limit == 0 {
+			limit = matchHistoryDefaultLimit
+		}
+		if limit < 1 || limit > matchHistoryMaxLimit {
+			return "", errInvalidLimit
+		}
+
+		objects, cursor, err := nk.StorageList(ctx, "", userID, matchHistoryCollection, limit, request.Cursor)
+		if err != nil {
+			logger.Error("StorageList error: %v", err)
+			return "", errInternalError
+		}
+
+		entries := make([]*api.MatchHistoryEntry, 0, len(objects))
+		for _, object := range objects {
+			entry := &api.MatchHistoryEntry{}
+			if err := unmarshaler.Unmarshal([]byte(object.GetValue()), entry); err != nil {
+				logger.Error("Unmarshal error: %v", err)
+				return "", errUnmarshal
+			}
+			entries = append(entries, entry)
+		}
+
+		response, err := marshaler.Marshal(&api.RpcListMatchHistoryResponse{Entries: entries, Cursor: cursor})
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(response), nil
+	}
+}
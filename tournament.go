@@ -0,0 +1,573 @@
+// Copyright 2023 The Nakama Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	nkapi "github.com/heroiclabs/nakama-common/api"
+	"github.com/heroiclabs/nakama-common/runtime"
+	"github.com/heroiclabs/nakama-project-template/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	notificationCodeTournamentMatch = 109
+	notificationCodeTournamentPrize = 110
+
+	// Defaults for the tournament, which runs every Saturday at 18:00.
+	tournamentDefaultID       = "weekly_cup"
+	tournamentDefaultSchedule = "0 18 * * 6"
+	tournamentDurationSec     = 4 * 60 * 60
+	tournamentMaxSize         = 64
+	// Players can join during the start of each run, then the bracket is drawn.
+	tournamentSignupSec = 30 * 60
+	// Every pairing plays a series, so it can't end in a draw.
+	tournamentBestOf = 3
+	// How long players have to join their match after it's created, or lose.
+	tournamentReservationSec = 120
+	// How often the server checks whether a bracket is due to be drawn.
+	tournamentDrawCheckInterval = time.Minute
+
+	// Brackets are owned by the system user, so only the server can change them.
+	tournamentBracketCollection = "tournament_bracket"
+	// Prizes each player has been paid, keyed by the run's bracket key, so a run never pays out twice.
+	tournamentPrizeCollection = "tournament_prize"
+	tournamentWriteAttempts   = 3
+)
+
+// Coins for the champion, the runner-up and the losing semifinalists.
+var tournamentPrizes = []int64{10000, 5000, 2000}
+
+// A prize paid to a player for a run of the tournament.
+type tournamentPrize struct {
+	Tournament string `json:"tournament"`
+	EndUnix    int64  `json:"end_unix"`
+	Coins      int64  `json:"coins"`
+}
+
+// Where a tournament match sits in its bracket.
+type tournamentSlot struct {
+	matchID      string
+	tournamentID string
+	bracketKey   string
+	round        int
+	pairing      int
+}
+
+// Each run of the tournament has its own bracket, keyed by when the run ends.
+func tournamentBracketKey(tournamentID string, endTime int64) string {
+	return fmt.Sprintf("%s.%d", tournamentID, endTime)
+}
+
+// Create the tournament, if it doesn't exist yet. Players must join it before the bracket is drawn.
+func createTournament(ctx context.Context, nk runtime.NakamaModule, tournamentID, resetSchedule string) error {
+	return nk.TournamentCreate(ctx, tournamentID, true, "desc", "best", resetSchedule, map[string]interface{}{},
+		"Weekly Cup", "Single elimination tic-tac-toe.", 0, 0, 0, tournamentDurationSec, tournamentMaxSize, 0, true, true)
+}
+
+func readTournamentBracket(ctx context.Context, nk runtime.NakamaModule, unmarshaler *protojson.UnmarshalOptions, key string) (*api.TournamentBracket, string, error) {
+	objects, err := nk.StorageRead(ctx, []*runtime.StorageRead{{
+		Collection: tournamentBracketCollection,
+		Key:        key,
+	}})
+	if err != nil {
+		return nil, "", err
+	}
+	if len(objects) == 0 {
+		return nil, "*", nil
+	}
+
+	b := &api.TournamentBracket{}
+	if err := unmarshaler.Unmarshal([]byte(objects[0].GetValue()), b); err != nil {
+		return nil, "", err
+	}
+	return b, objects[0].GetVersion(), nil
+}
+
+// Write the bracket, only if it hasn't changed since it was read at the given version.
+func writeTournamentBracket(ctx context.Context, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, key string, b *api.TournamentBracket, version string) error {
+	value, err := marshaler.Marshal(b)
+	if err != nil {
+		return err
+	}
+	_, err = nk.StorageWrite(ctx, []*runtime.StorageWrite{{
+		Collection:      tournamentBracketCollection,
+		Key:             key,
+		Value:           string(value),
+		Version:         version,
+		PermissionRead:  0, // No client read, brackets are fetched through the RPC.
+		PermissionWrite: 0, // No client write.
+	}})
+	return err
+}
+
+// Bracket positions for seeds 0 to size-1, where size is a power of 2. The top seeds can only meet in the later rounds.
+func seedOrder(size int) []int {
+	order := []int{0}
+	for len(order) < size {
+		next := make([]int, 0, len(order)*2)
+		for _, seed := range order {
+			next = append(next, seed, len(order)*2-1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// Draw the first round of a bracket, seeding players by rating. Top seeds get byes if the bracket isn't full.
+func newTournamentBracket(ctx context.Context, nk runtime.NakamaModule, tournamentID string, endTime int64, entrants []string) (*api.TournamentBracket, error) {
+	b := &api.TournamentBracket{TournamentId: tournamentID, EndTime: endTime}
+	if len(entrants) < 2 {
+		return b, nil
+	}
+
+	ratings, _, err := readRatings(ctx, nk, ratingKey(moduleName, false), entrants...)
+	if err != nil {
+		return nil, err
+	}
+	seeds := make([]int, len(entrants))
+	for i := range seeds {
+		seeds[i] = i
+	}
+	sort.SliceStable(seeds, func(i, j int) bool {
+		return ratings[seeds[i]].Rating > ratings[seeds[j]].Rating
+	})
+
+	size := 2
+	for size < len(entrants) {
+		size *= 2
+	}
+	player := func(seed int) string {
+		if seed < len(seeds) {
+			return entrants[seeds[seed]]
+		}
+		return ""
+	}
+	order := seedOrder(size)
+	round := &api.TournamentRound{}
+	for i := 0; i < size; i += 2 {
+		pairing := &api.TournamentPairing{PlayerA: player(order[i]), PlayerB: player(order[i+1])}
+		if pairing.PlayerB == "" {
+			pairing.Winner = pairing.PlayerA
+		}
+		round.Pairings = append(round.Pairings, pairing)
+	}
+	b.Rounds = append(b.Rounds, round)
+	return b, nil
+}
+
+// Seed of every player in a bracket, 0 being the top seed, worked out from where they were drawn in the first round.
+func tournamentSeeds(b *api.TournamentBracket) map[string]int {
+	seeds := make(map[string]int)
+	if len(b.Rounds) == 0 {
+		return seeds
+	}
+	order := seedOrder(len(b.Rounds[0].Pairings) * 2)
+	for i, pairing := range b.Rounds[0].Pairings {
+		seeds[pairing.PlayerA] = order[i*2]
+		if pairing.PlayerB != "" {
+			seeds[pairing.PlayerB] = order[i*2+1]
+		}
+	}
+	return seeds
+}
+
+// Once every pairing in the latest round has a winner, draw the next round from them, until there's a champion. The
+// higher seed is always player A.
+func advanceTournamentBracket(b *api.TournamentBracket) {
+	seeds := tournamentSeeds(b)
+	for b.Winner == "" && len(b.Rounds) > 0 {
+		round := b.Rounds[len(b.Rounds)-1]
+		for _, pairing := range round.Pairings {
+			if pairing.Winner == "" {
+				return
+			}
+		}
+
+		if len(round.Pairings) == 1 {
+			b.Winner = round.Pairings[0].Winner
+			return
+		}
+		next := &api.TournamentRound{}
+		for i := 0; i < len(round.Pairings); i += 2 {
+			playerA, playerB := round.Pairings[i].Winner, round.Pairings[i+1].Winner
+			if seeds[playerB] < seeds[playerA] {
+				playerA, playerB = playerB, playerA
+			}
+			next.Pairings = append(next.Pairings, &api.TournamentPairing{PlayerA: playerA, PlayerB: playerB})
+		}
+		b.Rounds = append(b.Rounds, next)
+	}
+}
+
+// Create matches for the pairings in the latest round that don't have one, then record them in the bracket. Only the
+// writer that drew the round gets here, so matches are never created twice for a pairing. The write is retried on any
+// error, as a match that can't be recorded is never announced and its result is ignored.
+func startTournamentMatches(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, key string) {
+	b, version, err := readTournamentBracket(ctx, nk, unmarshaler, key)
+	if err != nil {
+		logger.Error("error reading tournament bracket: %v", err)
+		return
+	}
+	if b == nil || b.Winner != "" || len(b.Rounds) == 0 {
+		return
+	}
+
+	roundIndex := len(b.Rounds) - 1
+	matchIDs := make(map[int]string)
+	for i, pairing := range b.Rounds[roundIndex].Pairings {
+		if pairing.Winner != "" || pairing.MatchId != "" {
+			continue
+		}
+		matchID, err := nk.MatchCreate(ctx, moduleName, map[string]interface{}{
+			"fast": false, "best_of": tournamentBestOf, "reserved": []string{pairing.PlayerA, pairing.PlayerB},
+			"reservation_sec": tournamentReservationSec,
+			"tournament_id":   b.TournamentId, "bracket_key": key,
+			"bracket_round": roundIndex, "bracket_pairing": i})
+		if err != nil {
+			logger.Error("error creating tournament match: %v", err)
+			continue
+		}
+		matchIDs[i] = matchID
+	}
+	if len(matchIDs) == 0 {
+		return
+	}
+
+	for attempt := 1; ; attempt++ {
+		var created []*api.TournamentPairing
+		for i, matchID := range matchIDs {
+			pairing := b.Rounds[roundIndex].Pairings[i]
+			if pairing.Winner == "" && pairing.MatchId == "" {
+				pairing.MatchId = matchID
+			}
+			if pairing.MatchId == matchID {
+				created = append(created, pairing)
+			}
+		}
+
+		err := writeTournamentBracket(ctx, nk, marshaler, key, b, version)
+		if err == nil {
+			notifyTournamentMatches(ctx, logger, nk, b, created)
+			return
+		}
+		if attempt >= tournamentWriteAttempts {
+			logger.Error("error writing tournament bracket: %v", err)
+			return
+		}
+		if !isVersionConflict(err) {
+			logger.Warn("error writing tournament bracket, retrying: %v", err)
+		}
+
+		// Start again from the latest bracket, which may already have these matches if the failed write went through.
+		b, version, err = readTournamentBracket(ctx, nk, unmarshaler, key)
+		if err != nil {
+			logger.Error("error reading tournament bracket: %v", err)
+			return
+		}
+		if b == nil || roundIndex >= len(b.Rounds) {
+			return
+		}
+	}
+}
+
+// Let players know their next tournament match is ready to join.
+func notifyTournamentMatches(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, b *api.TournamentBracket, pairings []*api.TournamentPairing) {
+	var notifications []*runtime.NotificationSend
+	for _, pairing := range pairings {
+		for _, userID := range []string{pairing.PlayerA, pairing.PlayerB} {
+			notifications = append(notifications, &runtime.NotificationSend{
+				UserID:  userID,
+				Subject: "Your tournament match is ready!",
+				Content: map[string]interface{}{
+					"tournament_id": b.TournamentId,
+					"match_id":      pairing.MatchId,
+					"round":         len(b.Rounds),
+				},
+				Code:       notificationCodeTournamentMatch,
+				Persistent: true,
+			})
+		}
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := nk.NotificationsSend(ctx, notifications); err != nil {
+		logger.Error("NotificationsSend error: %v", err)
+	}
+}
+
+// Draw the bracket for the tournament's current run from the players that joined, unless it's been drawn already.
+func drawTournamentBracket(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, tournament *nkapi.Tournament) error {
+	endTime := int64(tournament.GetEndActive())
+	key := tournamentBracketKey(tournament.GetId(), endTime)
+	b, _, err := readTournamentBracket(ctx, nk, unmarshaler, key)
+	if err != nil || b != nil {
+		return err
+	}
+
+	records, _, _, _, err := nk.TournamentRecordsList(ctx, tournament.GetId(), nil, tournamentMaxSize, "", 0)
+	if err != nil {
+		return err
+	}
+	entrants := make([]string, 0, len(records))
+	for _, record := range records {
+		entrants = append(entrants, record.GetOwnerId())
+	}
+
+	b, err = newTournamentBracket(ctx, nk, tournament.GetId(), endTime, entrants)
+	if err != nil {
+		return err
+	}
+	advanceTournamentBracket(b)
+	if err := writeTournamentBracket(ctx, nk, marshaler, key, b, "*"); err != nil {
+		if isVersionConflict(err) {
+			// Another node drew the bracket first, and starts its matches.
+			return nil
+		}
+		return err
+	}
+
+	startTournamentMatches(ctx, logger, nk, marshaler, unmarshaler, key)
+	return nil
+}
+
+// Draw the bracket of the tournament's current run if joining has closed. Returns when joining closes.
+func drawDueTournamentBracket(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, tournamentID string) (time.Time, error) {
+	tournaments, err := nk.TournamentsGetId(ctx, []string{tournamentID})
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(tournaments) == 0 {
+		return time.Time{}, fmt.Errorf("tournament %q not found", tournamentID)
+	}
+	tournament := tournaments[0]
+
+	signupEnd := time.Unix(int64(tournament.GetStartActive())+tournamentSignupSec, 0)
+	now := time.Now()
+	if tournament.GetStartActive() == 0 || now.Before(signupEnd) || now.Unix() >= int64(tournament.GetEndActive()) {
+		return signupEnd, nil
+	}
+	return signupEnd, drawTournamentBracket(ctx, logger, nk, marshaler, unmarshaler, tournament)
+}
+
+// Draw each run's bracket on the server as soon as joining closes. Every node checks, but only one draws each bracket.
+func scheduleTournamentDraws(logger runtime.Logger, nk runtime.NakamaModule, marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, tournamentID string) {
+	go func() {
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), tournamentDrawCheckInterval)
+			signupEnd, err := drawDueTournamentBracket(ctx, logger, nk, marshaler, unmarshaler, tournamentID)
+			cancel()
+			if err != nil {
+				logger.Error("error drawing tournament bracket: %v", err)
+			}
+
+			delay := tournamentDrawCheckInterval
+			if until := time.Until(signupEnd); until > 0 && until < delay {
+				delay = until
+			}
+			time.Sleep(delay)
+		}
+	}()
+}
+
+// Record the winner of a tournament match in its bracket, and start the next matches if the round is complete.
+func (m *MatchHandler) reportTournamentResult(ctx context.Context, logger runtime.Logger, nk runtime.NakamaModule, slot *tournamentSlot, winner, username string) {
+	for attempt := 1; ; attempt++ {
+		b, version, err := readTournamentBracket(ctx, nk, m.unmarshaler, slot.bracketKey)
+		if err != nil {
+			logger.Error("error reading tournament bracket: %v", err)
+			return
+		}
+		if b == nil || slot.round >= len(b.Rounds) || slot.pairing >= len(b.Rounds[slot.round].Pairings) {
+			logger.Error("tournament bracket %q has no pairing for this match", slot.bracketKey)
+			return
+		}
+
+		pairing := b.Rounds[slot.round].Pairings[slot.pairing]
+		if pairing.MatchId != slot.matchID {
+			logger.Warn("tournament bracket %q has another match for this pairing, ignoring the result", slot.bracketKey)
+			return
+		}
+		if pairing.Winner != "" {
+			return
+		}
+		pairing.Winner = winner
+		if pairing.Winner == "" {
+			// Neither player turned up, or the series was drawn, the higher seed goes through. They're always player A.
+			pairing.Winner = pairing.PlayerA
+		}
+
+		rounds := len(b.Rounds)
+		advanceTournamentBracket(b)
+		if err := writeTournamentBracket(ctx, nk, m.marshaler, slot.bracketKey, b, version); err != nil {
+			if attempt >= tournamentWriteAttempts || !isVersionConflict(err) {
+				logger.Error("error writing tournament bracket: %v", err)
+				return
+			}
+			continue
+		}
+
+		if pairing.Winner != winner {
+			// The higher seed went through, their username isn't known here.
+			username = ""
+		}
+		if _, err := nk.TournamentRecordWrite(ctx, slot.tournamentID, pairing.Winner, username, int64(slot.round+1), 0, nil, nil); err != nil {
+			logger.WithField("user_id", pairing.Winner).Error("error writing tournament record: %v", err)
+		}
+		if len(b.Rounds) > rounds {
+			startTournamentMatches(ctx, logger, nk, m.marshaler, m.unmarshaler, slot.bracketKey)
+		}
+		return
+	}
+}
+
+// Coins each player has won, by how far they got in a finished bracket.
+func tournamentPlacings(b *api.TournamentBracket) map[string]int64 {
+	prizes := map[string]int64{b.Winner: tournamentPrizes[0]}
+	for place := 1; place < len(tournamentPrizes) && place <= len(b.Rounds); place++ {
+		for _, pairing := range b.Rounds[len(b.Rounds)-place].Pairings {
+			loser := pairing.PlayerA
+			if loser == pairing.Winner {
+				loser = pairing.PlayerB
+			}
+			if loser != "" {
+				prizes[loser] = tournamentPrizes[place]
+			}
+		}
+	}
+	return prizes
+}
+
+// Pay out prizes when a run of the tournament ends. Each prize is written in the same update as a marker for the run, and
+// only if the player doesn't have one yet, so nobody is paid twice if the hook runs again.
+func tournamentEnd(tournamentID string, unmarshaler *protojson.UnmarshalOptions) func(context.Context, runtime.Logger, *sql.DB, runtime.NakamaModule, *nkapi.Tournament, int64, int64) error {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, tournament *nkapi.Tournament, end, reset int64) error {
+		if tournament.GetId() != tournamentID {
+			return nil
+		}
+
+		key := tournamentBracketKey(tournamentID, end)
+		b, _, err := readTournamentBracket(ctx, nk, unmarshaler, key)
+		if err != nil {
+			logger.Error("error reading tournament bracket: %v", err)
+			return err
+		}
+		if b == nil || b.Winner == "" {
+			logger.Warn("tournament %q ended without a winner", tournamentID)
+			return nil
+		}
+
+		var payErr error
+		var notifications []*runtime.NotificationSend
+		for userID, coins := range tournamentPlacings(b) {
+			prize, err := json.Marshal(&tournamentPrize{Tournament: tournamentID, EndUnix: end, Coins: coins})
+			if err != nil {
+				return err
+			}
+			writes := []*runtime.StorageWrite{{
+				Collection:      tournamentPrizeCollection,
+				Key:             key,
+				UserID:          userID,
+				Value:           string(prize),
+				Version:         "*", // Only if the player hasn't been paid for this run yet.
+				PermissionRead:  1,
+				PermissionWrite: 0, // No client write.
+			}}
+			wallets := []*runtime.WalletUpdate{{
+				UserID:    userID,
+				Changeset: map[string]int64{"coins": coins},
+				Metadata:  map[string]interface{}{"tournament": tournamentID, "end_unix": end},
+			}}
+			if _, _, err := nk.MultiUpdate(ctx, nil, writes, nil, wallets, true); err != nil {
+				if isVersionConflict(err) {
+					// Already paid, by an earlier run of this hook.
+					continue
+				}
+				logger.WithField("user_id", userID).Error("error paying tournament prize: %v", err)
+				if payErr == nil {
+					payErr = err
+				}
+				continue
+			}
+
+			notifications = append(notifications, &runtime.NotificationSend{
+				UserID:  userID,
+				Subject: "You've won a tournament prize!",
+				Content: map[string]interface{}{
+					"tournament_id": tournamentID,
+					"winner":        b.Winner,
+					"coins":         coins,
+				},
+				Code:       notificationCodeTournamentPrize,
+				Persistent: true,
+			})
+		}
+
+		if len(notifications) > 0 {
+			if err := nk.NotificationsSend(ctx, notifications); err != nil {
+				logger.Error("NotificationsSend error: %v", err)
+			}
+		}
+		return payErr
+	}
+}
+
+func rpcGetTournamentBracket(marshaler *protojson.MarshalOptions, unmarshaler *protojson.UnmarshalOptions, tournamentID string) nakamaRpcFunc {
+	return func(ctx context.Context, logger runtime.Logger, db *sql.DB, nk runtime.NakamaModule, payload string) (string, error) {
+		if len(payload) > 0 {
+			return "", errNoInputAllowed
+		}
+
+		tournaments, err := nk.TournamentsGetId(ctx, []string{tournamentID})
+		if err != nil {
+			logger.Error("TournamentsGetId error: %v", err)
+			return "", errInternalError
+		}
+		if len(tournaments) == 0 {
+			logger.Error("tournament %q not found", tournamentID)
+			return "", errInternalError
+		}
+		tournament := tournaments[0]
+
+		// The bracket is drawn by the server once joining closes.
+		response := &api.RpcGetTournamentBracketResponse{
+			SignupEndTime: int64(tournament.GetStartActive()) + tournamentSignupSec,
+		}
+		now := time.Now().Unix()
+		if tournament.GetStartActive() > 0 && now >= response.SignupEndTime && now < int64(tournament.GetEndActive()) {
+			key := tournamentBracketKey(tournamentID, int64(tournament.GetEndActive()))
+			response.Bracket, _, err = readTournamentBracket(ctx, nk, unmarshaler, key)
+			if err != nil {
+				logger.Error("error reading tournament bracket: %v", err)
+				return "", errInternalError
+			}
+		}
+
+		buf, err := marshaler.Marshal(response)
+		if err != nil {
+			logger.Error("error marshaling response payload: %v", err.Error())
+			return "", errMarshal
+		}
+
+		return string(buf), nil
+	}
+}